@@ -0,0 +1,317 @@
+package collections
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/bits"
+)
+
+const wordSize = 64
+
+// ErrInvalidBitSet is returned when unmarshalling data which isn't a valid binary
+// representation of a BitSet.
+var ErrInvalidBitSet = errors.New("invalid binary representation of BitSet")
+
+// BitSet is a compact set of non-negative integers backed by a slice of 64-bit
+// words, using a single bit per possible element. For dense sets of small integers,
+// such as user IDs or feature flags, BitSet uses a fraction of the memory of
+// Set[int], and set operations work on 64 elements at a time.
+//
+// The memory used by a BitSet is proportional to the largest element rather than
+// the number of elements, so it isn't suitable for sparse sets of large integers.
+// Indexes must be non-negative, methods given a negative index will panic.
+//
+// BitSet supports marshaling/unmarshalling as binary through
+// encoding.BinaryMarshaler, and as JSON where it is encoded as a base64 string of
+// the binary representation.
+//
+// The zero-value of BitSet is an empty BitSet ready to use.
+//
+// Note: BitSet is not thread safe.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates and initializes a new BitSet with capacity for the bits 0 up
+// to size without growing. The size must be greater than or equal to 0 otherwise
+// NewBitSet will panic.
+func NewBitSet(size int) *BitSet {
+	if size < 0 {
+		panic("size cannot be less than 0")
+	}
+	return &BitSet{
+		words: make([]uint64, 0, (size+wordSize-1)/wordSize),
+	}
+}
+
+// Set sets the bit at index i, adding i to the BitSet.
+func (b *BitSet) Set(i int) {
+	w := wordIndex(i)
+	b.grow(w + 1)
+	b.words[w] |= 1 << (uint(i) % wordSize)
+}
+
+// Clear clears the bit at index i, removing i from the BitSet.
+func (b *BitSet) Clear(i int) {
+	w := wordIndex(i)
+	if w < len(b.words) {
+		b.words[w] &^= 1 << (uint(i) % wordSize)
+	}
+}
+
+// Test returns true if the bit at index i is set.
+func (b *BitSet) Test(i int) bool {
+	w := wordIndex(i)
+	return w < len(b.words) && b.words[w]&(1<<(uint(i)%wordSize)) != 0
+}
+
+// Flip toggles the bit at index i.
+func (b *BitSet) Flip(i int) {
+	w := wordIndex(i)
+	b.grow(w + 1)
+	b.words[w] ^= 1 << (uint(i) % wordSize)
+}
+
+// Count returns the number of bits set.
+func (b *BitSet) Count() int {
+	count := 0
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// IsEmpty returns true if no bits are set.
+func (b *BitSet) IsEmpty() bool {
+	for _, word := range b.words {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NextSet returns the index of the first bit set at or after index i and true. If
+// there is no such bit 0 and false are returned.
+func (b *BitSet) NextSet(i int) (int, bool) {
+	w := wordIndex(i)
+	if w >= len(b.words) {
+		return 0, false
+	}
+	// Ignore the bits before i in the first word
+	word := b.words[w] >> (uint(i) % wordSize)
+	if word != 0 {
+		return i + bits.TrailingZeros64(word), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return w*wordSize + bits.TrailingZeros64(b.words[w]), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear returns the index of the first bit not set at or after index i. Since
+// a BitSet is conceptually infinite there is always such a bit.
+func (b *BitSet) NextClear(i int) int {
+	w := wordIndex(i)
+	if w >= len(b.words) {
+		return i
+	}
+	word := ^b.words[w] >> (uint(i) % wordSize)
+	if word != 0 {
+		return i + bits.TrailingZeros64(word)
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != ^uint64(0) {
+			return w*wordSize + bits.TrailingZeros64(^b.words[w])
+		}
+	}
+	return len(b.words) * wordSize
+}
+
+// And modifies this BitSet to only keep the bits also set in other, the
+// intersection of both sets.
+func (b *BitSet) And(other *BitSet) {
+	n := minInt(len(b.words), len(other.words))
+	for i := 0; i < n; i++ {
+		b.words[i] &= other.words[i]
+	}
+	zeroFill(b.words[n:])
+	b.trim()
+}
+
+// Or modifies this BitSet to also set the bits set in other, the union of both
+// sets.
+func (b *BitSet) Or(other *BitSet) {
+	b.grow(len(other.words))
+	for i, word := range other.words {
+		b.words[i] |= word
+	}
+}
+
+// Xor modifies this BitSet to only keep the bits set in exactly one of this BitSet
+// and other, the symmetric difference of both sets.
+func (b *BitSet) Xor(other *BitSet) {
+	b.grow(len(other.words))
+	for i, word := range other.words {
+		b.words[i] ^= word
+	}
+	b.trim()
+}
+
+// AndNot modifies this BitSet to clear the bits set in other, the set difference
+// b \ other.
+func (b *BitSet) AndNot(other *BitSet) {
+	n := minInt(len(b.words), len(other.words))
+	for i := 0; i < n; i++ {
+		b.words[i] &^= other.words[i]
+	}
+	b.trim()
+}
+
+// ClearAll clears all the bits in the BitSet.
+func (b *BitSet) ClearAll() {
+	zeroFill(b.words)
+	b.words = b.words[:0]
+}
+
+// Equals returns true if both BitSets have the same bits set.
+func (b *BitSet) Equals(other *BitSet) bool {
+	shorter, longer := b.words, other.words
+	if len(shorter) > len(longer) {
+		shorter, longer = longer, shorter
+	}
+	for i, word := range shorter {
+		if word != longer[i] {
+			return false
+		}
+	}
+	for _, word := range longer[len(shorter):] {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a new BitSet with the same bits set.
+func (b *BitSet) Clone() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &BitSet{words: words}
+}
+
+// ForEach iterates through the indexes of the bits set in ascending order passing
+// each index to the provided function.
+func (b *BitSet) ForEach(fn func(i int)) {
+	for w, word := range b.words {
+		for word != 0 {
+			fn(w*wordSize + bits.TrailingZeros64(word))
+			// Clear the lowest set bit
+			word &= word - 1
+		}
+	}
+}
+
+// AsSlice returns the indexes of the bits set in ascending order.
+func (b *BitSet) AsSlice() []int {
+	indexes := make([]int, 0, b.Count())
+	b.ForEach(func(i int) {
+		indexes = append(indexes, i)
+	})
+	return indexes
+}
+
+// MarshalBinary marshals a BitSet into binary representation, which is the words
+// of the BitSet in little endian byte order. Trailing words without any bits set
+// are omitted.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	words := b.words
+	for len(words) > 0 && words[len(words)-1] == 0 {
+		words = words[:len(words)-1]
+	}
+	data := make([]byte, len(words)*8)
+	for i, word := range words {
+		binary.LittleEndian.PutUint64(data[i*8:], word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary unmarshalls the binary representation of a BitSet into this
+// instance of BitSet, replacing its contents.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return ErrInvalidBitSet
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	b.words = words
+	return nil
+}
+
+// MarshalJSON marshals a BitSet into JSON as a base64 string of its binary
+// representation.
+func (b *BitSet) MarshalJSON() ([]byte, error) {
+	data, err := b.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(base64.StdEncoding.EncodeToString(data))
+}
+
+// UnmarshalJSON unmarshalls a base64 string of the binary representation of a
+// BitSet into this instance of BitSet, replacing its contents.
+func (b *BitSet) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+	return b.UnmarshalBinary(raw)
+}
+
+// grow extends the words of the BitSet to at least n words.
+func (b *BitSet) grow(n int) {
+	if n <= len(b.words) {
+		return
+	}
+	if n <= cap(b.words) {
+		// Bits are cleared when trimmed so the words are already zeroed
+		b.words = b.words[:n]
+		return
+	}
+	words := make([]uint64, n, maxInt(n, 2*cap(b.words)))
+	copy(words, b.words)
+	b.words = words
+}
+
+// trim removes trailing words without any bits set.
+func (b *BitSet) trim() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+}
+
+func wordIndex(i int) int {
+	if i < 0 {
+		panic("index cannot be negative")
+	}
+	return i / wordSize
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,235 @@
+package collections
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bitSetOf(indexes ...int) *BitSet {
+	b := NewBitSet(0)
+	for _, i := range indexes {
+		b.Set(i)
+	}
+	return b
+}
+
+func TestBitSet(t *testing.T) {
+	b := NewBitSet(128)
+	assert.True(t, b.IsEmpty())
+	assert.False(t, b.Test(5))
+
+	b.Set(0)
+	b.Set(63)
+	b.Set(64)
+	b.Set(1000)
+	b.Set(64)
+	assert.True(t, b.Test(0))
+	assert.True(t, b.Test(63))
+	assert.True(t, b.Test(64))
+	assert.True(t, b.Test(1000))
+	assert.False(t, b.Test(1))
+	assert.False(t, b.Test(100000))
+	assert.Equal(t, 4, b.Count())
+	assert.Equal(t, []int{0, 63, 64, 1000}, b.AsSlice())
+
+	b.Clear(63)
+	b.Clear(5000)
+	assert.False(t, b.Test(63))
+	assert.Equal(t, 3, b.Count())
+
+	b.Flip(1)
+	b.Flip(0)
+	b.Flip(2000)
+	assert.Equal(t, []int{1, 64, 1000, 2000}, b.AsSlice())
+
+	b.ClearAll()
+	assert.True(t, b.IsEmpty())
+	assert.Equal(t, 0, b.Count())
+	b.Set(70)
+	assert.Equal(t, []int{70}, b.AsSlice())
+
+	assert.Panics(t, func() { b.Set(-1) })
+	assert.Panics(t, func() { b.Test(-1) })
+	assert.Panics(t, func() { NewBitSet(-1) })
+}
+
+func TestBitSet_ZeroValue(t *testing.T) {
+	var b BitSet
+	assert.True(t, b.IsEmpty())
+	b.Set(3)
+	assert.True(t, b.Test(3))
+	assert.Equal(t, 1, b.Count())
+}
+
+func TestBitSet_NextSet(t *testing.T) {
+	b := bitSetOf(3, 64, 200)
+
+	tests := []struct {
+		from     int
+		expected int
+		found    bool
+	}{
+		{0, 3, true},
+		{3, 3, true},
+		{4, 64, true},
+		{65, 200, true},
+		{200, 200, true},
+		{201, 0, false},
+		{10000, 0, false},
+	}
+	for _, test := range tests {
+		i, ok := b.NextSet(test.from)
+		assert.Equal(t, test.found, ok, "NextSet(%d)", test.from)
+		assert.Equal(t, test.expected, i, "NextSet(%d)", test.from)
+	}
+
+	var visited []int
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		visited = append(visited, i)
+	}
+	assert.Equal(t, []int{3, 64, 200}, visited)
+}
+
+func TestBitSet_NextClear(t *testing.T) {
+	b := NewBitSet(0)
+	for i := 0; i < 130; i++ {
+		b.Set(i)
+	}
+	b.Clear(70)
+
+	assert.Equal(t, 70, b.NextClear(0))
+	assert.Equal(t, 70, b.NextClear(70))
+	assert.Equal(t, 130, b.NextClear(71))
+	assert.Equal(t, 500, b.NextClear(500))
+
+	full := NewBitSet(0)
+	for i := 0; i < 128; i++ {
+		full.Set(i)
+	}
+	assert.Equal(t, 128, full.NextClear(0))
+	assert.Equal(t, 0, NewBitSet(0).NextClear(0))
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	a := bitSetOf(1, 2, 3, 100, 300)
+	b := bitSetOf(2, 3, 4, 300)
+
+	and := a.Clone()
+	and.And(b)
+	assert.Equal(t, []int{2, 3, 300}, and.AsSlice())
+
+	or := a.Clone()
+	or.Or(b)
+	assert.Equal(t, []int{1, 2, 3, 4, 100, 300}, or.AsSlice())
+
+	xor := a.Clone()
+	xor.Xor(b)
+	assert.Equal(t, []int{1, 4, 100}, xor.AsSlice())
+
+	andNot := a.Clone()
+	andNot.AndNot(b)
+	assert.Equal(t, []int{1, 100}, andNot.AsSlice())
+
+	// Operations between sets of different lengths
+	short := bitSetOf(1)
+	short.And(a)
+	assert.Equal(t, []int{1}, short.AsSlice())
+	long := a.Clone()
+	long.And(bitSetOf(1))
+	assert.Equal(t, []int{1}, long.AsSlice())
+
+	// The receiver isn't modified by Clone
+	assert.Equal(t, []int{1, 2, 3, 100, 300}, a.AsSlice())
+}
+
+func TestBitSet_Equals(t *testing.T) {
+	a := bitSetOf(1, 500)
+	b := bitSetOf(1)
+	assert.False(t, a.Equals(b))
+	assert.False(t, b.Equals(a))
+
+	b.Set(500)
+	assert.True(t, a.Equals(b))
+
+	// Trailing words without bits set are ignored
+	a.Set(1000)
+	a.Clear(1000)
+	assert.True(t, a.Equals(b))
+	assert.True(t, b.Equals(a))
+	assert.True(t, NewBitSet(0).Equals(NewBitSet(1024)))
+}
+
+func TestBitSet_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	b := NewBitSet(0)
+	expected := NewSet[int]()
+	for i := 0; i < 5000; i++ {
+		v := r.Intn(2000)
+		switch r.Intn(3) {
+		case 0:
+			b.Set(v)
+			expected.Add(v)
+		case 1:
+			b.Clear(v)
+			expected.Remove(v)
+		case 2:
+			b.Flip(v)
+			if expected.Contains(v) {
+				expected.Remove(v)
+			} else {
+				expected.Add(v)
+			}
+		}
+	}
+
+	assert.Equal(t, expected.Size(), b.Count())
+	b.ForEach(func(i int) {
+		assert.True(t, expected.Contains(i))
+	})
+	for i := 0; i < 2000; i++ {
+		assert.Equal(t, expected.Contains(i), b.Test(i))
+	}
+}
+
+func TestBitSet_MarshalBinary(t *testing.T) {
+	b := bitSetOf(0, 9, 64, 129)
+	b.Set(1000)
+	b.Clear(1000)
+
+	data, err := b.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Len(t, data, 24)
+
+	var decoded BitSet
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.True(t, b.Equals(&decoded))
+	assert.Equal(t, []int{0, 9, 64, 129}, decoded.AsSlice())
+
+	data, err = NewBitSet(0).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+
+	assert.ErrorIs(t, decoded.UnmarshalBinary([]byte{1, 2, 3}), ErrInvalidBitSet)
+}
+
+func TestBitSet_MarshalJSON(t *testing.T) {
+	type flags struct {
+		Enabled *BitSet `json:"enabled"`
+	}
+	in := flags{Enabled: bitSetOf(1, 2, 70)}
+
+	data, err := json.Marshal(in)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"enabled":"BgAAAAAAAABAAAAAAAAAAA=="}`, string(data))
+
+	var out flags
+	assert.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, []int{1, 2, 70}, out.Enabled.AsSlice())
+
+	var b BitSet
+	assert.Error(t, json.Unmarshal([]byte(`[1, 2]`), &b))
+	assert.Error(t, json.Unmarshal([]byte(`"not base64!"`), &b))
+}
@@ -0,0 +1,637 @@
+package collections
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrUnsortedInput is returned when data that must be sorted in strictly ascending
+// order is not.
+var ErrUnsortedInput = errors.New("input is not sorted in strictly ascending order")
+
+// BTree is an in-memory B-tree which keeps its entries sorted by key. Each node
+// holds many entries in a contiguous slice which makes BTree cache friendly and
+// memory efficient for large sorted datasets compared to binary trees.
+//
+// The degree controls the size of the nodes, every node other than the root holds
+// between degree-1 and 2*degree-1 entries. Set, Get and Delete are O(log n)
+// operations. A BTree can be loaded from sorted input in O(n) using Load.
+//
+// Clone returns a copy of the BTree in O(1). The original and the clone share
+// their nodes lazily, a node is only copied the first time either tree modifies
+// it.
+//
+// The zero-value of BTree is not usable. NewBTree or NewBTreeFunc should be used
+// to create and initialize a new instance of BTree.
+//
+// Note: BTree is not thread safe. A BTree and its clones may however be used from
+// different goroutines since they never modify shared nodes.
+type BTree[K any, V any] struct {
+	root   *btreeNode[K, V]
+	size   int
+	degree int
+	cmp    func(a, b K) int
+	// cow identifies the nodes owned by this tree, which can be modified in
+	// place. Nodes owned by any other value are shared with a clone.
+	cow *cowOwner
+}
+
+// cowOwner has a non-zero size since pointers to distinct zero-size values are
+// not guaranteed to be different.
+type cowOwner struct {
+	_ byte
+}
+
+type btreeNode[K any, V any] struct {
+	items    []Pair[K, V]
+	children []*btreeNode[K, V]
+	cow      *cowOwner
+}
+
+// NewBTree creates and initializes a new BTree with the given degree ordering keys
+// by their natural order. The degree must be greater than or equal to 2 otherwise
+// NewBTree will panic.
+func NewBTree[K Ordered, V any](degree int) *BTree[K, V] {
+	return NewBTreeFunc[K, V](degree, Compare[K])
+}
+
+// NewBTreeFunc creates and initializes a new BTree with the given degree ordering
+// keys using the provided comparator. The comparator must return a negative number
+// if a is less than b, zero if they are equal and a positive number if a is
+// greater than b. The degree must be greater than or equal to 2 otherwise
+// NewBTreeFunc will panic.
+func NewBTreeFunc[K any, V any](degree int, cmp func(a, b K) int) *BTree[K, V] {
+	if degree < 2 {
+		panic("degree cannot be less than 2")
+	}
+	if cmp == nil {
+		panic("comparator cannot be nil")
+	}
+	return &BTree[K, V]{
+		degree: degree,
+		cmp:    cmp,
+		cow:    &cowOwner{},
+	}
+}
+
+// Load replaces the contents of the BTree with the provided entries in O(n). The
+// entries must be sorted by key in strictly ascending order according to the
+// comparator of the BTree, otherwise ErrUnsortedInput is returned and the BTree
+// is left unmodified.
+func (t *BTree[K, V]) Load(entries []Pair[K, V]) error {
+	for i := 1; i < len(entries); i++ {
+		if t.cmp(entries[i-1].Key, entries[i].Key) >= 0 {
+			return ErrUnsortedInput
+		}
+	}
+	t.root = nil
+	t.size = len(entries)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// Find the smallest height that can hold all the entries. The weight of a
+	// subtree is its number of entries plus one, a subtree of height h can have
+	// a weight of at most (2*degree)^(h+1).
+	height := 0
+	for capacity := 2 * t.degree; len(entries)+1 > capacity; capacity *= 2 * t.degree {
+		height++
+	}
+	t.root = t.build(entries, height)
+	return nil
+}
+
+// Set inserts a new key/value into the BTree or replaces the value for an existing
+// key. If the key didn't exist in the BTree and was inserted true is returned.
+// Otherwise, if they key was already existing returns false.
+func (t *BTree[K, V]) Set(key K, val V) bool {
+	if t.root == nil {
+		t.root = t.newNode()
+		t.root.items = append(t.root.items, Pair[K, V]{Key: key, Value: val})
+		t.size++
+		return true
+	}
+	t.root = t.mutable(t.root)
+	if len(t.root.items) == t.maxItems() {
+		oldRoot := t.root
+		t.root = t.newNode()
+		t.root.children = append(t.root.children, oldRoot)
+		t.splitChild(t.root, 0)
+	}
+	inserted := t.insertNonFull(t.root, key, val)
+	if inserted {
+		t.size++
+	}
+	return inserted
+}
+
+// Contains returns true if the given key exists in the BTree, otherwise returns
+// false.
+func (t *BTree[K, V]) Contains(key K) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Get retrieves the value for a key. It follows the same idioms of the built-in
+// map. If the key doesn't exist the zero value and false value are returned.
+func (t *BTree[K, V]) Get(key K) (V, bool) {
+	for n := t.root; n != nil; {
+		i, found := t.search(n, key)
+		if found {
+			return n.items[i].Value, true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+// GetOrDefault retrieves the value for a key and if it doesn't exist returns the
+// provided default value.
+func (t *BTree[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if val, ok := t.Get(key); ok {
+		return val
+	}
+	return defaultValue
+}
+
+// Delete removes a key/value entry from the BTree. If the key didn't exist returns
+// false, otherwise returns true if the entry was deleted.
+func (t *BTree[K, V]) Delete(key K) bool {
+	_, _, ok := t.deleteFromRoot(key, removeKey)
+	return ok
+}
+
+// DeleteMin removes and returns the entry with the smallest key. If the BTree is
+// empty the zero value of the key and value and false are returned.
+func (t *BTree[K, V]) DeleteMin() (K, V, bool) {
+	var zero K
+	return t.deleteFromRoot(zero, removeMin)
+}
+
+// DeleteMax removes and returns the entry with the largest key. If the BTree is
+// empty the zero value of the key and value and false are returned.
+func (t *BTree[K, V]) DeleteMax() (K, V, bool) {
+	var zero K
+	return t.deleteFromRoot(zero, removeMax)
+}
+
+// Min returns the entry with the smallest key. If the BTree is empty the zero
+// value of the key and value and false are returned.
+func (t *BTree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		return pairEntry[K, V](nil)
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return pairEntry(&n.items[0])
+}
+
+// Max returns the entry with the largest key. If the BTree is empty the zero value
+// of the key and value and false are returned.
+func (t *BTree[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		return pairEntry[K, V](nil)
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return pairEntry(&n.items[len(n.items)-1])
+}
+
+// Clone returns a copy of the BTree in O(1). The nodes are shared between the
+// BTree and the clone until either of them modifies a node, at which point that
+// tree makes its own copy of the node. Values are copied by assignment so the
+// clone is a shallow copy.
+func (t *BTree[K, V]) Clone() *BTree[K, V] {
+	// Both trees take a new owner so neither modifies the nodes now shared.
+	clone := *t
+	clone.cow = &cowOwner{}
+	t.cow = &cowOwner{}
+	return &clone
+}
+
+// Clear removes all the entries from the BTree.
+func (t *BTree[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+// Size returns the number of entries in the BTree.
+func (t *BTree[K, V]) Size() int {
+	return t.size
+}
+
+// Degree returns the degree of the BTree.
+func (t *BTree[K, V]) Degree() int {
+	return t.degree
+}
+
+// Keys returns the keys in ascending order.
+func (t *BTree[K, V]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	t.ForEach(func(key K, val V) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// ForEach iterates through the entries in ascending key order passing the
+// key/value pair to the provided function/closure. The BTree must not be
+// modified while iterating.
+func (t *BTree[K, V]) ForEach(fn func(key K, val V)) {
+	for it := t.Iter(); it.Next(); {
+		fn(it.Key(), it.Value())
+	}
+}
+
+// Iter returns a stateful iterator positioned before the entry with the smallest
+// key.
+func (t *BTree[K, V]) Iter() *BTreeIterator[K, V] {
+	it := &BTreeIterator[K, V]{tree: t}
+	it.pushLeftmost(t.root)
+	return it
+}
+
+// IterFrom returns a stateful iterator positioned before the entry with the
+// smallest key greater than or equal to the given key.
+func (t *BTree[K, V]) IterFrom(key K) *BTreeIterator[K, V] {
+	it := &BTreeIterator[K, V]{tree: t}
+	it.Seek(key)
+	return it
+}
+
+func (t *BTree[K, V]) maxItems() int {
+	return 2*t.degree - 1
+}
+
+func (t *BTree[K, V]) minItems() int {
+	return t.degree - 1
+}
+
+func (t *BTree[K, V]) newNode() *btreeNode[K, V] {
+	return &btreeNode[K, V]{
+		items: make([]Pair[K, V], 0, t.maxItems()),
+		cow:   t.cow,
+	}
+}
+
+// mutable returns n if it is owned by this tree, otherwise returns a copy of n
+// owned by this tree. The caller must replace its reference to n with the
+// returned node.
+func (t *BTree[K, V]) mutable(n *btreeNode[K, V]) *btreeNode[K, V] {
+	if n.cow == t.cow {
+		return n
+	}
+	c := t.newNode()
+	c.items = append(c.items, n.items...)
+	if !n.leaf() {
+		c.children = make([]*btreeNode[K, V], len(n.children), t.maxItems()+1)
+		copy(c.children, n.children)
+	}
+	return c
+}
+
+// mutableChild makes the i'th child of n mutable and returns it. n must already be
+// mutable.
+func (t *BTree[K, V]) mutableChild(n *btreeNode[K, V], i int) *btreeNode[K, V] {
+	n.children[i] = t.mutable(n.children[i])
+	return n.children[i]
+}
+
+// search returns the index of the first entry in n with a key greater than or
+// equal to the given key and whether that entry has the given key.
+func (t *BTree[K, V]) search(n *btreeNode[K, V], key K) (int, bool) {
+	i := sort.Search(len(n.items), func(i int) bool {
+		return t.cmp(n.items[i].Key, key) >= 0
+	})
+	return i, i < len(n.items) && t.cmp(n.items[i].Key, key) == 0
+}
+
+// build creates a subtree of the given height from sorted entries. The entries are
+// spread evenly over the fewest levels, so every node satisfies the minimum and
+// maximum number of entries.
+func (t *BTree[K, V]) build(entries []Pair[K, V], height int) *btreeNode[K, V] {
+	n := t.newNode()
+	if height == 0 {
+		n.items = append(n.items, entries...)
+		return n
+	}
+
+	// Each child is a subtree of height-1 which must have a weight between
+	// degree^height and (2*degree)^height. Using as many children as the minimum
+	// weight allows keeps every child under the maximum weight.
+	minWeight := 1
+	for i := 0; i < height; i++ {
+		minWeight *= t.degree
+	}
+	weight := len(entries) + 1
+	children := minInt(2*t.degree, weight/minWeight)
+	childWeight, extra := weight/children, weight%children
+
+	n.children = make([]*btreeNode[K, V], 0, t.maxItems()+1)
+	pos := 0
+	for i := 0; i < children; i++ {
+		w := childWeight
+		if i < extra {
+			w++
+		}
+		n.children = append(n.children, t.build(entries[pos:pos+w-1], height-1))
+		pos += w - 1
+		if i < children-1 {
+			n.items = append(n.items, entries[pos])
+			pos++
+		}
+	}
+	return n
+}
+
+// insertNonFull inserts the key/value into the subtree rooted at n, which must be
+// mutable and not full. Full nodes are split on the way down so there is always
+// room to insert into a leaf.
+func (t *BTree[K, V]) insertNonFull(n *btreeNode[K, V], key K, val V) bool {
+	for {
+		i, found := t.search(n, key)
+		if found {
+			n.items[i].Value = val
+			return false
+		}
+		if n.leaf() {
+			n.items = insertAt(n.items, i, Pair[K, V]{Key: key, Value: val})
+			return true
+		}
+		child := t.mutableChild(n, i)
+		if len(child.items) == t.maxItems() {
+			t.splitChild(n, i)
+			c := t.cmp(key, n.items[i].Key)
+			if c == 0 {
+				n.items[i].Value = val
+				return false
+			}
+			if c > 0 {
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// splitChild splits the full i'th child of n in two, moving its middle entry up
+// into n. Both n and the child must be mutable.
+func (t *BTree[K, V]) splitChild(n *btreeNode[K, V], i int) {
+	child := n.children[i]
+	mid := t.minItems()
+	right := t.newNode()
+	right.items = append(right.items, child.items[mid+1:]...)
+	if !child.leaf() {
+		right.children = make([]*btreeNode[K, V], 0, t.maxItems()+1)
+		right.children = append(right.children, child.children[mid+1:]...)
+		zeroFill(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+	n.items = insertAt(n.items, i, child.items[mid])
+	n.children = insertAt(n.children, i+1, right)
+	zeroFill(child.items[mid:])
+	child.items = child.items[:mid]
+}
+
+type removeType int
+
+const (
+	removeKey removeType = iota
+	removeMin
+	removeMax
+)
+
+func (t *BTree[K, V]) deleteFromRoot(key K, typ removeType) (K, V, bool) {
+	if t.root == nil {
+		return pairEntry[K, V](nil)
+	}
+	t.root = t.mutable(t.root)
+	removed, ok := t.remove(t.root, key, typ)
+	if len(t.root.items) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	if !ok {
+		return pairEntry[K, V](nil)
+	}
+	t.size--
+	return removed.Key, removed.Value, true
+}
+
+// remove removes an entry from the subtree rooted at n, which must be mutable.
+// Before descending into a child it is grown so it has more than the minimum
+// number of entries, which guarantees removing from it never underflows.
+func (t *BTree[K, V]) remove(n *btreeNode[K, V], key K, typ removeType) (Pair[K, V], bool) {
+	var i int
+	var found bool
+	switch typ {
+	case removeMin:
+		if n.leaf() {
+			return removeAt(&n.items, 0), true
+		}
+		i = 0
+	case removeMax:
+		if n.leaf() {
+			return removeAt(&n.items, len(n.items)-1), true
+		}
+		i = len(n.items)
+	default:
+		i, found = t.search(n, key)
+		if n.leaf() {
+			if !found {
+				return Pair[K, V]{}, false
+			}
+			return removeAt(&n.items, i), true
+		}
+	}
+
+	if len(n.children[i].items) <= t.minItems() {
+		t.growChild(n, i)
+		return t.remove(n, key, typ)
+	}
+	child := t.mutableChild(n, i)
+	if found {
+		// Replace the entry with its predecessor which is the largest entry in
+		// the child to its left.
+		removed := n.items[i]
+		n.items[i], _ = t.remove(child, key, removeMax)
+		return removed, true
+	}
+	return t.remove(child, key, typ)
+}
+
+// growChild ensures the i'th child of n has more than the minimum number of
+// entries, by borrowing an entry from a sibling or by merging with a sibling. n
+// must be mutable.
+func (t *BTree[K, V]) growChild(n *btreeNode[K, V], i int) {
+	switch {
+	case i > 0 && len(n.children[i-1].items) > t.minItems():
+		// Borrow from the left sibling
+		child := t.mutableChild(n, i)
+		left := t.mutableChild(n, i-1)
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = removeAt(&left.items, len(left.items)-1)
+		if !left.leaf() {
+			child.children = insertAt(child.children, 0, removeAt(&left.children, len(left.children)-1))
+		}
+
+	case i < len(n.items) && len(n.children[i+1].items) > t.minItems():
+		// Borrow from the right sibling
+		child := t.mutableChild(n, i)
+		right := t.mutableChild(n, i+1)
+		child.items = append(child.items, n.items[i])
+		n.items[i] = removeAt(&right.items, 0)
+		if !right.leaf() {
+			child.children = append(child.children, removeAt(&right.children, 0))
+		}
+
+	default:
+		// Merge with a sibling, always merging the right node into the left
+		if i >= len(n.items) {
+			i--
+		}
+		child := t.mutableChild(n, i)
+		sibling := removeAt(&n.children, i+1)
+		child.items = append(child.items, removeAt(&n.items, i))
+		child.items = append(child.items, sibling.items...)
+		child.children = append(child.children, sibling.children...)
+	}
+}
+
+func (n *btreeNode[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// BTreeIterator is a stateful iterator over the entries of a BTree in ascending
+// key order. The BTree must not be modified while iterating.
+//
+// Example:
+//
+//	for it := tree.IterFrom(key); it.Next(); {
+//		fmt.Println(it.Key(), it.Value())
+//	}
+type BTreeIterator[K any, V any] struct {
+	tree *BTree[K, V]
+	// stack holds the path to the next entry, each frame holds the index of the
+	// next entry to return from its node.
+	stack   []btreeFrame[K, V]
+	current *Pair[K, V]
+}
+
+type btreeFrame[K any, V any] struct {
+	node  *btreeNode[K, V]
+	index int
+}
+
+// Next advances the iterator to the next entry and returns true, or returns false
+// if there are no more entries.
+func (it *BTreeIterator[K, V]) Next() bool {
+	for len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		if top.index >= len(top.node.items) {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		n := top.node
+		it.current = &n.items[top.index]
+		top.index++
+		if !n.leaf() {
+			it.pushLeftmost(n.children[top.index])
+		}
+		return true
+	}
+	it.current = nil
+	return false
+}
+
+// Seek repositions the iterator before the entry with the smallest key greater
+// than or equal to the given key.
+func (it *BTreeIterator[K, V]) Seek(key K) {
+	it.stack = it.stack[:0]
+	it.current = nil
+	for n := it.tree.root; n != nil; {
+		i, _ := it.tree.search(n, key)
+		it.stack = append(it.stack, btreeFrame[K, V]{node: n, index: i})
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+}
+
+// Key returns the key of the current entry. Key should only be called after Next
+// has returned true.
+func (it *BTreeIterator[K, V]) Key() K {
+	return it.current.Key
+}
+
+// Value returns the value of the current entry. Value should only be called after
+// Next has returned true.
+func (it *BTreeIterator[K, V]) Value() V {
+	return it.current.Value
+}
+
+func (it *BTreeIterator[K, V]) pushLeftmost(n *btreeNode[K, V]) {
+	for ; n != nil; n = n.children[0] {
+		it.stack = append(it.stack, btreeFrame[K, V]{node: n})
+		if n.leaf() {
+			return
+		}
+	}
+}
+
+func pairEntry[K any, V any](p *Pair[K, V]) (K, V, bool) {
+	if p == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return p.Key, p.Value, true
+}
+
+// insertAt inserts val at index i of s shifting the following elements.
+func insertAt[T any](s []T, i int, val T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = val
+	return s
+}
+
+// removeAt removes and returns the element at index i of s shifting the following
+// elements. The vacated slot is zeroed so the element can be garbage collected.
+func removeAt[T any](s *[]T, i int) T {
+	val := (*s)[i]
+	copy((*s)[i:], (*s)[i+1:])
+	var zero T
+	(*s)[len(*s)-1] = zero
+	*s = (*s)[:len(*s)-1]
+	return val
+}
+
+// zeroFill sets every element of s to its zero value so they can be garbage
+// collected.
+func zeroFill[T any](s []T) {
+	var zero T
+	for i := range s {
+		s[i] = zero
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,271 @@
+package collections
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBTree(t *testing.T) {
+	bt := NewBTree[int, string](2)
+	assert.True(t, bt.Set(2, "two"))
+	assert.True(t, bt.Set(1, "one"))
+	assert.True(t, bt.Set(3, "three"))
+	assert.False(t, bt.Set(1, "uno"))
+
+	assert.Equal(t, 3, bt.Size())
+	assert.Equal(t, 2, bt.Degree())
+	assert.Equal(t, []int{1, 2, 3}, bt.Keys())
+
+	val, ok := bt.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "uno", val)
+	_, ok = bt.Get(4)
+	assert.False(t, ok)
+	assert.Equal(t, "four", bt.GetOrDefault(4, "four"))
+	assert.True(t, bt.Contains(3))
+
+	assert.True(t, bt.Delete(2))
+	assert.False(t, bt.Delete(2))
+	assert.Equal(t, []int{1, 3}, bt.Keys())
+
+	bt.Clear()
+	assert.Equal(t, 0, bt.Size())
+	assert.Empty(t, bt.Keys())
+	_, _, ok = bt.Min()
+	assert.False(t, ok)
+
+	assert.Panics(t, func() {
+		NewBTree[int, string](1)
+	})
+}
+
+func TestBTree_MinMax(t *testing.T) {
+	bt := NewBTree[int, int](3)
+	_, _, ok := bt.DeleteMin()
+	assert.False(t, ok)
+	_, _, ok = bt.DeleteMax()
+	assert.False(t, ok)
+
+	for i := 100; i > 0; i-- {
+		bt.Set(i, i*10)
+	}
+	key, val, ok := bt.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, key)
+	assert.Equal(t, 10, val)
+	key, _, _ = bt.Max()
+	assert.Equal(t, 100, key)
+
+	for i := 1; i <= 50; i++ {
+		key, val, ok = bt.DeleteMin()
+		assert.True(t, ok)
+		assert.Equal(t, i, key)
+		assert.Equal(t, i*10, val)
+
+		key, _, ok = bt.DeleteMax()
+		assert.True(t, ok)
+		assert.Equal(t, 101-i, key)
+		assertValidBTree(t, bt)
+	}
+	assert.Equal(t, 0, bt.Size())
+}
+
+func TestBTree_Load(t *testing.T) {
+	for _, degree := range []int{2, 3, 8} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 7, 15, 16, 17, 100, 1000, 4097} {
+			entries := make([]Pair[int, int], n)
+			for i := range entries {
+				entries[i] = Pair[int, int]{Key: i * 2, Value: i}
+			}
+
+			bt := NewBTree[int, int](degree)
+			bt.Set(-1, -1)
+			assert.NoError(t, bt.Load(entries))
+			assert.Equal(t, n, bt.Size())
+			assertValidBTree(t, bt)
+
+			i := 0
+			bt.ForEach(func(key int, val int) {
+				assert.Equal(t, entries[i].Key, key)
+				assert.Equal(t, entries[i].Value, val)
+				i++
+			})
+			assert.Equal(t, n, i)
+
+			// The loaded tree must remain valid when modified
+			for i := 0; i < n; i += 3 {
+				bt.Set(i*2+1, i)
+				bt.Delete(i * 2)
+			}
+			assertValidBTree(t, bt)
+		}
+	}
+
+	bt := NewBTree[int, int](2)
+	bt.Set(1, 1)
+	err := bt.Load([]Pair[int, int]{{Key: 2}, {Key: 2}})
+	assert.ErrorIs(t, err, ErrUnsortedInput)
+	assert.Equal(t, []int{1}, bt.Keys())
+}
+
+func TestBTree_Iter(t *testing.T) {
+	bt := NewBTree[int, int](2)
+	for i := 0; i < 100; i += 2 {
+		bt.Set(i, i)
+	}
+
+	var keys []int
+	for it := bt.Iter(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, bt.Keys(), keys)
+	assert.Len(t, keys, 50)
+
+	tests := []struct {
+		name string
+		seek int
+		want []int
+	}{
+		{"exact", 90, []int{90, 92, 94, 96, 98}},
+		{"between", 91, []int{92, 94, 96, 98}},
+		{"before first", -5, []int{0, 2, 4}},
+		{"after last", 99, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var keys []int
+			for it := bt.IterFrom(test.seek); it.Next() && len(keys) < len(test.want); {
+				keys = append(keys, it.Key())
+				assert.Equal(t, it.Key(), it.Value())
+			}
+			assert.Equal(t, test.want, keys)
+		})
+	}
+
+	it := bt.Iter()
+	assert.True(t, it.Next())
+	assert.Equal(t, 0, it.Key())
+	it.Seek(50)
+	assert.True(t, it.Next())
+	assert.Equal(t, 50, it.Key())
+
+	assert.False(t, NewBTree[int, int](2).Iter().Next())
+}
+
+func TestBTree_Clone(t *testing.T) {
+	bt := NewBTree[int, int](2)
+	for i := 0; i < 200; i++ {
+		bt.Set(i, i)
+	}
+
+	clone := bt.Clone()
+	for i := 0; i < 200; i += 2 {
+		clone.Delete(i)
+	}
+	clone.Set(1, -1)
+	bt.Set(1000, 1000)
+
+	assert.Equal(t, 201, bt.Size())
+	assert.Equal(t, 100, clone.Size())
+	assert.Equal(t, 1, bt.GetOrDefault(1, 0))
+	assert.Equal(t, -1, clone.GetOrDefault(1, 0))
+	assert.True(t, bt.Contains(0))
+	assert.False(t, clone.Contains(0))
+	assert.False(t, clone.Contains(1000))
+	assertValidBTree(t, bt)
+	assertValidBTree(t, clone)
+
+	// Cloning a clone keeps all three independent
+	clone2 := clone.Clone()
+	clone2.Clear()
+	assert.Equal(t, 100, clone.Size())
+}
+
+func TestBTree_Random(t *testing.T) {
+	for _, degree := range []int{2, 3, 5} {
+		rng := rand.New(rand.NewSource(int64(degree)))
+		bt := NewBTree[int, int](degree)
+		reference := make(map[int]int)
+		var snapshot *BTree[int, int]
+		var snapshotKeys []int
+
+		for i := 0; i < 5000; i++ {
+			key := rng.Intn(500)
+			_, exists := reference[key]
+			if rng.Intn(3) == 0 {
+				assert.Equal(t, exists, bt.Delete(key))
+				delete(reference, key)
+			} else {
+				assert.Equal(t, !exists, bt.Set(key, i))
+				reference[key] = i
+			}
+			if i%500 == 0 {
+				assertValidBTree(t, bt)
+				if snapshot != nil {
+					assert.Equal(t, snapshotKeys, snapshot.Keys())
+				}
+				snapshot = bt.Clone()
+				snapshotKeys = snapshot.Keys()
+			}
+		}
+
+		keys := make([]int, 0, len(reference))
+		for key := range reference {
+			keys = append(keys, key)
+		}
+		sort.Ints(keys)
+		assert.Equal(t, keys, bt.Keys())
+		for key, val := range reference {
+			got, _ := bt.Get(key)
+			assert.Equal(t, val, got)
+		}
+		assertValidBTree(t, bt)
+	}
+}
+
+// assertValidBTree checks every node other than the root holds between degree-1
+// and 2*degree-1 entries, internal nodes have one more child than entries, all
+// the leaves are at the same depth and the keys are sorted.
+func assertValidBTree[K any, V any](t *testing.T, bt *BTree[K, V]) {
+	t.Helper()
+	if bt.root == nil {
+		assert.Equal(t, 0, bt.size)
+		return
+	}
+
+	leafDepth := -1
+	count := 0
+	var walk func(n *btreeNode[K, V], depth int)
+	walk = func(n *btreeNode[K, V], depth int) {
+		count += len(n.items)
+		assert.LessOrEqual(t, len(n.items), bt.maxItems())
+		if n != bt.root {
+			assert.GreaterOrEqual(t, len(n.items), bt.minItems())
+		}
+		if n.leaf() {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+			assert.Equal(t, leafDepth, depth, "leaves at different depths")
+			return
+		}
+		assert.Equal(t, len(n.items)+1, len(n.children))
+		for _, child := range n.children {
+			walk(child, depth+1)
+		}
+	}
+	walk(bt.root, 0)
+	assert.Equal(t, bt.size, count)
+
+	var prev *K
+	for it := bt.Iter(); it.Next(); {
+		key := it.Key()
+		if prev != nil {
+			assert.Negative(t, bt.cmp(*prev, key), "keys out of order")
+		}
+		prev = &key
+	}
+}
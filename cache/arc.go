@@ -0,0 +1,286 @@
+package cache
+
+import (
+	"sync"
+)
+
+// ARC is a thread safe fixed capacity Adaptive Replacement Cache. ARC balances
+// between recency and frequency by tracking entries that have been used once
+// (recent) and entries that have been used more than once (frequent) in separate
+// lists. It also tracks the keys, but not the values, recently evicted from each
+// list (ghosts) and uses hits on those ghosts to adapt how much of the capacity
+// is given to each list.
+//
+// ARC is resistant to scans, a one-time pass over many keys only churns the recent
+// list leaving the frequently used entries cached.
+//
+// The zero-value of ARC is not usable. NewARC should be used to create and
+// initialize a new instance of ARC.
+type ARC[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	// target is the adaptive target size of the recent list.
+	target int
+
+	recent         *keyedList[K, V]
+	frequent       *keyedList[K, V]
+	recentGhosts   *keyedList[K, struct{}]
+	frequentGhosts *keyedList[K, struct{}]
+
+	onEvict EvictFunc[K, V]
+	stats   Stats
+}
+
+// NewARC creates and initializes a new ARC with the given capacity. The capacity
+// must be greater than or equal to 1 otherwise NewARC will panic. The optional
+// EvictFunc is invoked for each entry evicted from the cache, a nil EvictFunc is
+// allowed.
+func NewARC[K comparable, V any](capacity int, onEvict EvictFunc[K, V]) *ARC[K, V] {
+	if capacity < 1 {
+		panic("capacity cannot be less than 1")
+	}
+	return &ARC[K, V]{
+		capacity:       capacity,
+		recent:         newKeyedList[K, V](),
+		frequent:       newKeyedList[K, V](),
+		recentGhosts:   newKeyedList[K, struct{}](),
+		frequentGhosts: newKeyedList[K, struct{}](),
+		onEvict:        onEvict,
+	}
+}
+
+// Get retrieves the value for a key. An entry that is hit is moved to the most
+// recently used position of the frequent list. If the key doesn't exist the zero
+// value and false are returned.
+func (c *ARC[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if val, ok := c.recent.remove(key); ok {
+		c.stats.Hits++
+		c.frequent.pushBack(key, val)
+		return val, true
+	}
+	if elem, ok := c.frequent.get(key); ok {
+		c.stats.Hits++
+		c.frequent.moveToBack(elem)
+		return elem.Value, true
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Peek retrieves the value for a key without updating its position or affecting
+// the hit/miss stats.
+func (c *ARC[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.recent.get(key); ok {
+		return elem.Value, true
+	}
+	if elem, ok := c.frequent.get(key); ok {
+		return elem.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains returns true if the key exists in the cache without updating its
+// position.
+func (c *ARC[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recent.contains(key) || c.frequent.contains(key)
+}
+
+// Add inserts or updates the value for a key. If adding a new entry exceeds the
+// capacity an entry is evicted from either the recent or frequent list depending
+// on the adaptive target. Add returns true if an entry was evicted.
+func (c *ARC[K, V]) Add(key K, val V) bool {
+	c.mu.Lock()
+	var evicted []entry[K, V]
+
+	switch {
+	case c.recent.contains(key):
+		c.recent.remove(key)
+		c.frequent.pushBack(key, val)
+
+	case c.frequent.contains(key):
+		elem, _ := c.frequent.get(key)
+		elem.Value = val
+		c.frequent.moveToBack(elem)
+
+	case c.recentGhosts.contains(key):
+		// The recent list was too small, grow its target
+		delta := 1
+		if c.frequentGhosts.len() > c.recentGhosts.len() {
+			delta = c.frequentGhosts.len() / c.recentGhosts.len()
+		}
+		c.target = minInt(c.capacity, c.target+delta)
+		evicted = c.replace(false)
+		c.recentGhosts.remove(key)
+		c.frequent.pushBack(key, val)
+
+	case c.frequentGhosts.contains(key):
+		// The frequent list was too small, shrink the target of the recent list
+		delta := 1
+		if c.recentGhosts.len() > c.frequentGhosts.len() {
+			delta = c.recentGhosts.len() / c.frequentGhosts.len()
+		}
+		c.target = maxInt(0, c.target-delta)
+		evicted = c.replace(true)
+		c.frequentGhosts.remove(key)
+		c.frequent.pushBack(key, val)
+
+	default:
+		evicted = c.replace(false)
+		// Keep the ghost lists trimmed
+		if c.recentGhosts.len() > c.capacity-c.target {
+			c.recentGhosts.removeOldest()
+		}
+		if c.frequentGhosts.len() > c.target {
+			c.frequentGhosts.removeOldest()
+		}
+		c.recent.pushBack(key, val)
+	}
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, evicted)
+	return len(evicted) > 0
+}
+
+// Remove removes the entry for a key from the cache returning true if the key
+// existed. The EvictFunc is not invoked for removed entries.
+func (c *ARC[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recentGhosts.remove(key)
+	c.frequentGhosts.remove(key)
+	if _, ok := c.recent.remove(key); ok {
+		return true
+	}
+	_, ok := c.frequent.remove(key)
+	return ok
+}
+
+// Resize changes the capacity of the cache evicting entries, as Add would, if the
+// cache contains more entries than the new capacity. The ghost lists and the
+// adaptive target are trimmed to the new capacity. Resize returns the number of
+// entries evicted. The capacity must be greater than or equal to 1 otherwise
+// Resize will panic.
+func (c *ARC[K, V]) Resize(capacity int) int {
+	if capacity < 1 {
+		panic("capacity cannot be less than 1")
+	}
+	c.mu.Lock()
+	c.capacity = capacity
+	c.target = minInt(c.target, capacity)
+	var evicted []entry[K, V]
+	for c.recent.len()+c.frequent.len() > capacity {
+		evicted = append(evicted, c.evictOne(false)...)
+	}
+	for c.recentGhosts.len() > capacity {
+		c.recentGhosts.removeOldest()
+	}
+	for c.frequentGhosts.len() > capacity {
+		c.frequentGhosts.removeOldest()
+	}
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, evicted)
+	return len(evicted)
+}
+
+// Purge removes all the entries, and the ghost entries, from the cache. The
+// EvictFunc is not invoked for purged entries.
+func (c *ARC[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.target = 0
+	c.recent = newKeyedList[K, V]()
+	c.frequent = newKeyedList[K, V]()
+	c.recentGhosts = newKeyedList[K, struct{}]()
+	c.frequentGhosts = newKeyedList[K, struct{}]()
+}
+
+// Keys returns the keys in the cache. The keys of the recent list are returned
+// first followed by the keys of the frequent list, each ordered from least
+// recently used to most recently used.
+func (c *ARC[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append(c.recent.keys(), c.frequent.keys()...)
+}
+
+// Len returns the number of entries in the cache.
+func (c *ARC[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recent.len() + c.frequent.len()
+}
+
+// Capacity returns the capacity of the cache.
+func (c *ARC[K, V]) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capacity
+}
+
+// Stats returns a copy of the hit, miss and eviction counters of the cache.
+func (c *ARC[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// replace evicts an entry if the cache is full, moving the evicted key to the
+// corresponding ghost list. The caller must hold the lock.
+func (c *ARC[K, V]) replace(frequentGhostHit bool) []entry[K, V] {
+	if c.recent.len()+c.frequent.len() < c.capacity {
+		return nil
+	}
+	return c.evictOne(frequentGhostHit)
+}
+
+// evictOne evicts a single entry, moving the evicted key to the corresponding
+// ghost list. Whether the entry is evicted from the recent or frequent list
+// depends on the adaptive target, but if the chosen list is empty the entry is
+// evicted from the other list. Each ghost list holds at most capacity keys. The
+// caller must hold the lock.
+func (c *ARC[K, V]) evictOne(frequentGhostHit bool) []entry[K, V] {
+	recentLen := c.recent.len()
+	if recentLen > 0 && (recentLen > c.target || (recentLen == c.target && frequentGhostHit) || c.frequent.len() == 0) {
+		key, val, _ := c.recent.removeOldest()
+		c.recentGhosts.pushBack(key, struct{}{})
+		if c.recentGhosts.len() > c.capacity {
+			c.recentGhosts.removeOldest()
+		}
+		c.stats.Evictions++
+		return []entry[K, V]{{key: key, val: val}}
+	}
+
+	key, val, ok := c.frequent.removeOldest()
+	if !ok {
+		return nil
+	}
+	c.frequentGhosts.pushBack(key, struct{}{})
+	if c.frequentGhosts.len() > c.capacity {
+		c.frequentGhosts.removeOldest()
+	}
+	c.stats.Evictions++
+	return []entry[K, V]{{key: key, val: val}}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
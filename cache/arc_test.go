@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewARC(t *testing.T) {
+	assert.NotPanics(t, func() {
+		c := NewARC[string, int](10, nil)
+		assert.Equal(t, 10, c.Capacity())
+		assert.Equal(t, 0, c.Len())
+	})
+
+	assert.Panics(t, func() {
+		_ = NewARC[string, int](0, nil)
+	})
+}
+
+func TestARC_Add(t *testing.T) {
+	var evicted []string
+	c := NewARC[string, int](2, func(key string, val int) {
+		evicted = append(evicted, key)
+	})
+
+	assert.False(t, c.Add("one", 1))
+	assert.False(t, c.Add("two", 2))
+	assert.Equal(t, []string{"one", "two"}, c.Keys())
+
+	// Accessing an entry again promotes it to the frequent list
+	c.Get("one")
+	assert.Equal(t, []string{"two", "one"}, c.Keys())
+
+	assert.True(t, c.Add("three", 3))
+	assert.Equal(t, []string{"two"}, evicted)
+	assert.True(t, c.recentGhosts.contains("two"))
+
+	// A hit on a ghost adapts the target and brings the key back as frequent
+	assert.True(t, c.Add("two", 22))
+	assert.Equal(t, 1, c.target)
+	assert.False(t, c.recentGhosts.contains("two"))
+	val, ok := c.Peek("two")
+	assert.True(t, ok)
+	assert.Equal(t, 22, val)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestARC_GhostHitCapacity(t *testing.T) {
+	c := NewARC[int, int](2, nil)
+	for _, key := range []int{1, 2, 3, 1, 4, 2} {
+		c.Add(key, key)
+		assert.LessOrEqual(t, c.Len(), c.Capacity())
+	}
+
+	// A workload mixing ghost hits on both lists with gets and new keys
+	c = NewARC[int, int](8, nil)
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 10000; i++ {
+		key := r.Intn(32)
+		if r.Intn(3) == 0 {
+			c.Get(key)
+		} else {
+			c.Add(key, i)
+		}
+		assert.LessOrEqual(t, c.Len(), c.Capacity(), "step %d", i)
+		assert.LessOrEqual(t, c.recentGhosts.len(), c.Capacity())
+		assert.LessOrEqual(t, c.frequentGhosts.len(), c.Capacity())
+	}
+}
+
+func TestARC_ScanResistance(t *testing.T) {
+	c := NewARC[string, int](10, nil)
+	for i := 0; i < 5; i++ {
+		key := fmt.Sprintf("hot-%d", i)
+		c.Add(key, i)
+		c.Get(key)
+	}
+
+	// A one-time scan only churns the recent list
+	for i := 0; i < 100; i++ {
+		c.Add(fmt.Sprintf("scan-%d", i), i)
+	}
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, c.Contains(fmt.Sprintf("hot-%d", i)))
+	}
+	assert.Equal(t, 10, c.Len())
+	assert.LessOrEqual(t, c.recentGhosts.len(), 10)
+}
+
+func TestARC_Remove(t *testing.T) {
+	c := NewARC[string, int](2, nil)
+	c.Add("one", 1)
+	c.Add("two", 2)
+	c.Get("two")
+
+	assert.True(t, c.Remove("one"))
+	assert.True(t, c.Remove("two"))
+	assert.False(t, c.Remove("two"))
+	assert.Equal(t, 0, c.Len())
+
+	c.Add("one", 1)
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, Stats{Hits: 1}, c.Stats())
+}
+
+func TestARC_Resize(t *testing.T) {
+	var evicted []int
+	c := NewARC[int, int](6, func(key int, val int) {
+		evicted = append(evicted, key)
+	})
+	for i := 0; i < 6; i++ {
+		c.Add(i, i)
+	}
+	c.Get(4)
+	c.Get(5)
+	for i := 6; i < 12; i++ {
+		c.Add(i, i)
+	}
+
+	evicted = nil
+	assert.Equal(t, 4, c.Resize(2))
+	assert.Equal(t, 2, c.Capacity())
+	assert.Equal(t, 2, c.Len())
+	assert.Len(t, evicted, 4)
+	assert.LessOrEqual(t, c.target, 2)
+	assert.LessOrEqual(t, c.recentGhosts.len(), 2)
+	assert.LessOrEqual(t, c.frequentGhosts.len(), 2)
+
+	assert.Equal(t, 0, c.Resize(10))
+	for i := 20; i < 40; i++ {
+		c.Add(i, i)
+		assert.LessOrEqual(t, c.Len(), 10)
+	}
+	assert.Equal(t, 10, c.Len())
+
+	assert.Panics(t, func() {
+		c.Resize(0)
+	})
+}
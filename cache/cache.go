@@ -0,0 +1,144 @@
+// Package cache provides thread safe, fixed capacity, in-memory caches with
+// different eviction policies.
+package cache
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cache is a thread safe fixed capacity cache. Cache is implemented by LRU, LFU
+// and ARC which differ only in which entry is evicted when a new entry is added
+// to a full cache. Services can swap eviction policy through configuration by
+// creating the Cache with New.
+type Cache[K comparable, V any] interface {
+	// Get retrieves the value for a key, recording the access for the eviction
+	// policy.
+	Get(key K) (V, bool)
+	// Peek retrieves the value for a key without recording the access.
+	Peek(key K) (V, bool)
+	// Contains returns true if the key exists without recording the access.
+	Contains(key K) bool
+	// Add inserts or updates the value for a key returning true if an entry was
+	// evicted to make room.
+	Add(key K, val V) bool
+	// Remove removes the entry for a key returning true if the key existed.
+	Remove(key K) bool
+	// Resize changes the capacity of the cache evicting entries if needed,
+	// returning the number of entries evicted.
+	Resize(capacity int) int
+	// Purge removes all the entries.
+	Purge()
+	// Keys returns the keys in the cache.
+	Keys() []K
+	// Len returns the number of entries in the cache.
+	Len() int
+	// Capacity returns the capacity of the cache.
+	Capacity() int
+	// Stats returns the hit, miss and eviction counters.
+	Stats() Stats
+}
+
+var (
+	_ Cache[string, any] = (*LRU[string, any])(nil)
+	_ Cache[string, any] = (*LFU[string, any])(nil)
+	_ Cache[string, any] = (*ARC[string, any])(nil)
+)
+
+// Policy is the eviction policy of a Cache.
+type Policy int
+
+const (
+	// PolicyLRU evicts the least recently used entry.
+	PolicyLRU Policy = iota
+	// PolicyLFU evicts the least frequently used entry.
+	PolicyLFU
+	// PolicyARC uses an adaptive replacement cache balancing recency and frequency.
+	PolicyARC
+)
+
+// String returns the name of the Policy.
+func (p Policy) String() string {
+	switch p {
+	case PolicyLRU:
+		return "lru"
+	case PolicyLFU:
+		return "lfu"
+	case PolicyARC:
+		return "arc"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePolicy parses the name of a Policy, case-insensitive, as returned by
+// Policy.String.
+func ParsePolicy(name string) (Policy, error) {
+	switch strings.ToLower(name) {
+	case "lru":
+		return PolicyLRU, nil
+	case "lfu":
+		return PolicyLFU, nil
+	case "arc":
+		return PolicyARC, nil
+	default:
+		return 0, fmt.Errorf("unknown cache policy %q", name)
+	}
+}
+
+// New creates and initializes a new Cache using the given eviction Policy. The
+// capacity and EvictFunc follow the same semantics as NewLRU. New panics if the
+// Policy is unknown.
+func New[K comparable, V any](policy Policy, capacity int, onEvict EvictFunc[K, V]) Cache[K, V] {
+	switch policy {
+	case PolicyLRU:
+		return NewLRU[K, V](capacity, onEvict)
+	case PolicyLFU:
+		return NewLFU[K, V](capacity, onEvict)
+	case PolicyARC:
+		return NewARC[K, V](capacity, onEvict)
+	default:
+		panic(fmt.Sprintf("unknown cache policy %d", policy))
+	}
+}
+
+// EvictFunc is a function type that is invoked when an entry is evicted from a
+// cache to make room for a new entry. EvictFunc is not invoked for entries that
+// are explicitly removed.
+//
+// EvictFunc is invoked after the cache has released its lock, so it may safely
+// access the cache.
+type EvictFunc[K comparable, V any] func(key K, val V)
+
+// Stats holds the hit, miss and eviction counters of a cache.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRatio returns the ratio of hits to total lookups. If there have been no
+// lookups 0 is returned.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// entry is a key/value pair that was evicted from a cache and is pending the
+// invocation of the EvictFunc.
+type entry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+func notifyEvicted[K comparable, V any](fn EvictFunc[K, V], evicted []entry[K, V]) {
+	if fn == nil {
+		return
+	}
+	for _, e := range evicted {
+		fn(e.key, e.val)
+	}
+}
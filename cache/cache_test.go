@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	for _, policy := range []Policy{PolicyLRU, PolicyLFU, PolicyARC} {
+		t.Run(policy.String(), func(t *testing.T) {
+			c := New[string, int](policy, 2, nil)
+			c.Add("one", 1)
+			c.Add("two", 2)
+
+			val, ok := c.Get("one")
+			assert.True(t, ok)
+			assert.Equal(t, 1, val)
+
+			assert.True(t, c.Add("three", 3))
+			assert.Equal(t, 2, c.Len())
+			assert.True(t, c.Contains("one"))
+			assert.False(t, c.Contains("two"))
+		})
+	}
+
+	assert.Panics(t, func() {
+		_ = New[string, int](Policy(42), 2, nil)
+	})
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("LFU")
+	assert.NoError(t, err)
+	assert.Equal(t, PolicyLFU, policy)
+
+	_, err = ParsePolicy("fifo")
+	assert.Error(t, err)
+}
+
+func TestStats_HitRatio(t *testing.T) {
+	assert.Equal(t, 0.0, Stats{}.HitRatio())
+	assert.Equal(t, 0.75, Stats{Hits: 3, Misses: 1}.HitRatio())
+}
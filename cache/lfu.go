@@ -0,0 +1,261 @@
+package cache
+
+import (
+	"sync"
+)
+
+// LFU is a thread safe fixed capacity cache that evicts the least frequently used
+// entry when a new entry is added to a full cache. When multiple entries share the
+// lowest frequency the least recently used of them is evicted.
+//
+// LFU groups entries into buckets by their access frequency, kept in a list ordered
+// by frequency, which makes Get, Add, Peek, Remove and eviction O(1) operations.
+//
+// The zero-value of LFU is not usable. NewLFU should be used to create and
+// initialize a new instance of LFU.
+type LFU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	// entries holds the bucket for the current frequency of each entry.
+	entries map[K]*freqBucket[K, V]
+	// head is the bucket with the lowest frequency. The buckets form a list
+	// ordered by ascending frequency and only buckets holding entries are kept.
+	head    *freqBucket[K, V]
+	onEvict EvictFunc[K, V]
+	stats   Stats
+}
+
+// freqBucket holds the entries with the same access frequency ordered from least
+// recently used to most recently used.
+type freqBucket[K comparable, V any] struct {
+	freq  int
+	items *keyedList[K, V]
+	prev  *freqBucket[K, V]
+	next  *freqBucket[K, V]
+}
+
+// NewLFU creates and initializes a new LFU with the given capacity. The capacity
+// must be greater than or equal to 1 otherwise NewLFU will panic. The optional
+// EvictFunc is invoked for each entry evicted from the cache, a nil EvictFunc is
+// allowed.
+func NewLFU[K comparable, V any](capacity int, onEvict EvictFunc[K, V]) *LFU[K, V] {
+	if capacity < 1 {
+		panic("capacity cannot be less than 1")
+	}
+	return &LFU[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*freqBucket[K, V]),
+		onEvict:  onEvict,
+	}
+}
+
+// Get retrieves the value for a key and increments the access frequency of the
+// entry. If the key doesn't exist the zero value and false are returned.
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	val, _ := b.items.remove(key)
+	c.promote(key, val, b)
+	return val, true
+}
+
+// Peek retrieves the value for a key without incrementing the access frequency
+// of the entry or affecting the hit/miss stats.
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	elem, _ := b.items.get(key)
+	return elem.Value, true
+}
+
+// Contains returns true if the key exists in the cache without incrementing the
+// access frequency of the entry.
+func (c *LFU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Add inserts or updates the value for a key. Updating an existing entry counts
+// as an access and increments its frequency. If adding a new entry exceeds the
+// capacity the least frequently used entry is evicted. Add returns true if an
+// entry was evicted.
+func (c *LFU[K, V]) Add(key K, val V) bool {
+	c.mu.Lock()
+	if b, ok := c.entries[key]; ok {
+		b.items.remove(key)
+		c.promote(key, val, b)
+		c.mu.Unlock()
+		return false
+	}
+	evicted := c.evict(c.capacity - 1)
+	if c.head == nil || c.head.freq != 1 {
+		c.head = c.insertAfter(nil, 1)
+	}
+	c.head.items.pushBack(key, val)
+	c.entries[key] = c.head
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, evicted)
+	return len(evicted) > 0
+}
+
+// Remove removes the entry for a key from the cache returning true if the key
+// existed. The EvictFunc is not invoked for removed entries.
+func (c *LFU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	b.items.remove(key)
+	c.dropIfEmpty(b)
+	delete(c.entries, key)
+	return true
+}
+
+// Resize changes the capacity of the cache evicting the least frequently used
+// entries if the cache contains more entries than the new capacity. Resize
+// returns the number of entries evicted. The capacity must be greater than or
+// equal to 1 otherwise Resize will panic.
+func (c *LFU[K, V]) Resize(capacity int) int {
+	if capacity < 1 {
+		panic("capacity cannot be less than 1")
+	}
+	c.mu.Lock()
+	c.capacity = capacity
+	evicted := c.evict(capacity)
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, evicted)
+	return len(evicted)
+}
+
+// Purge removes all the entries from the cache. The EvictFunc is not invoked for
+// purged entries.
+func (c *LFU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[K]*freqBucket[K, V])
+	c.head = nil
+}
+
+// Keys returns the keys in the cache ordered from least frequently used to most
+// frequently used. Keys with the same frequency are ordered from least recently
+// used to most recently used.
+func (c *LFU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, len(c.entries))
+	for b := c.head; b != nil; b = b.next {
+		keys = append(keys, b.items.keys()...)
+	}
+	return keys
+}
+
+// Frequency returns the access frequency of a key, or 0 if the key doesn't exist.
+func (c *LFU[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if b, ok := c.entries[key]; ok {
+		return b.freq
+	}
+	return 0
+}
+
+// Len returns the number of entries in the cache.
+func (c *LFU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Capacity returns the capacity of the cache.
+func (c *LFU[K, V]) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capacity
+}
+
+// Stats returns a copy of the hit, miss and eviction counters of the cache.
+func (c *LFU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// promote moves an entry, which has already been removed from the bucket for its
+// current frequency, into the bucket for the next frequency. The caller must hold
+// the lock.
+func (c *LFU[K, V]) promote(key K, val V, b *freqBucket[K, V]) {
+	next := b.next
+	if next == nil || next.freq != b.freq+1 {
+		next = c.insertAfter(b, b.freq+1)
+	}
+	next.items.pushBack(key, val)
+	c.entries[key] = next
+	c.dropIfEmpty(b)
+}
+
+// insertAfter creates a bucket for a frequency and links it after the provided
+// bucket, or as the head if the provided bucket is nil.
+func (c *LFU[K, V]) insertAfter(prev *freqBucket[K, V], freq int) *freqBucket[K, V] {
+	b := &freqBucket[K, V]{freq: freq, items: newKeyedList[K, V](), prev: prev}
+	if prev == nil {
+		b.next = c.head
+		c.head = b
+	} else {
+		b.next = prev.next
+		prev.next = b
+	}
+	if b.next != nil {
+		b.next.prev = b
+	}
+	return b
+}
+
+// dropIfEmpty unlinks a bucket if it no longer holds any entries, so the head
+// always holds the entries with the lowest frequency.
+func (c *LFU[K, V]) dropIfEmpty(b *freqBucket[K, V]) {
+	if b.items.len() > 0 {
+		return
+	}
+	if b.prev == nil {
+		c.head = b.next
+	} else {
+		b.prev.next = b.next
+	}
+	if b.next != nil {
+		b.next.prev = b.prev
+	}
+}
+
+// evict removes the least frequently used entries until the cache holds no more
+// than size entries and returns the evicted entries. The caller must hold the
+// lock.
+func (c *LFU[K, V]) evict(size int) []entry[K, V] {
+	var evicted []entry[K, V]
+	for len(c.entries) > size {
+		b := c.head
+		key, val, _ := b.items.removeOldest()
+		c.dropIfEmpty(b)
+		delete(c.entries, key)
+		c.stats.Evictions++
+		evicted = append(evicted, entry[K, V]{key: key, val: val})
+	}
+	return evicted
+}
@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLFU(t *testing.T) {
+	assert.NotPanics(t, func() {
+		c := NewLFU[string, int](10, nil)
+		assert.Equal(t, 10, c.Capacity())
+		assert.Equal(t, 0, c.Len())
+	})
+
+	assert.Panics(t, func() {
+		_ = NewLFU[string, int](0, nil)
+	})
+}
+
+func TestLFU_Add(t *testing.T) {
+	var evicted []string
+	c := NewLFU[string, int](3, func(key string, val int) {
+		evicted = append(evicted, key)
+	})
+
+	c.Add("one", 1)
+	c.Add("two", 2)
+	c.Add("three", 3)
+	c.Get("one")
+	c.Get("one")
+	c.Get("three")
+
+	assert.True(t, c.Add("four", 4))
+	assert.Equal(t, []string{"two"}, evicted)
+
+	// Ties are broken by evicting the least recently used
+	assert.True(t, c.Add("five", 5))
+	assert.Equal(t, []string{"two", "four"}, evicted)
+	assert.Equal(t, []string{"five", "three", "one"}, c.Keys())
+
+	assert.False(t, c.Add("five", 55))
+	assert.Equal(t, 2, c.Frequency("five"))
+	assert.Equal(t, 3, c.Frequency("one"))
+	assert.Equal(t, 0, c.Frequency("two"))
+}
+
+func TestLFU_Get(t *testing.T) {
+	c := NewLFU[string, int](2, nil)
+	c.Add("one", 1)
+
+	val, ok := c.Get("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	_, ok = c.Get("missing")
+	assert.False(t, ok)
+
+	val, ok = c.Peek("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 2, c.Frequency("one"))
+	assert.True(t, c.Contains("one"))
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 1}, c.Stats())
+}
+
+func TestLFU_Remove(t *testing.T) {
+	c := NewLFU[string, int](3, nil)
+	c.Add("one", 1)
+	c.Add("two", 2)
+	c.Add("three", 3)
+	c.Get("two")
+	c.Get("three")
+
+	// Removing the only entry with the minimum frequency must not break eviction
+	assert.True(t, c.Remove("one"))
+	assert.False(t, c.Remove("one"))
+	c.Get("two")
+
+	assert.Equal(t, 1, c.Resize(1))
+	assert.Equal(t, []string{"two"}, c.Keys())
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+	c.Add("one", 1)
+	assert.Equal(t, []string{"one"}, c.Keys())
+}
+
+func TestLFU_Resize(t *testing.T) {
+	c := NewLFU[int, int](5, nil)
+	for i := 0; i < 5; i++ {
+		c.Add(i, i)
+		for j := 0; j < i; j++ {
+			c.Get(i)
+		}
+	}
+
+	assert.Equal(t, 3, c.Resize(2))
+	assert.Equal(t, []int{3, 4}, c.Keys())
+	assert.Equal(t, uint64(3), c.Stats().Evictions)
+}
+
+func TestLFU_Random(t *testing.T) {
+	type state struct {
+		freq int
+		tick int
+	}
+	model := make(map[int]state)
+	var evicted []int
+	c := NewLFU[int, int](8, func(key int, val int) {
+		evicted = append(evicted, key)
+	})
+
+	// expectedVictim returns the key with the lowest frequency, breaking ties by
+	// the least recent access.
+	expectedVictim := func() int {
+		victim, found := 0, false
+		for key, s := range model {
+			v := model[victim]
+			if !found || s.freq < v.freq || (s.freq == v.freq && s.tick < v.tick) {
+				victim, found = key, true
+			}
+		}
+		return victim
+	}
+
+	r := rand.New(rand.NewSource(11))
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(24)
+		switch r.Intn(10) {
+		case 0:
+			_, exists := model[key]
+			assert.Equal(t, exists, c.Remove(key))
+			delete(model, key)
+		case 1:
+			capacity := 1 + r.Intn(12)
+			victims := []int{}
+			for len(model) > capacity {
+				victim := expectedVictim()
+				victims = append(victims, victim)
+				delete(model, victim)
+			}
+			evicted = []int{}
+			assert.Equal(t, len(victims), c.Resize(capacity))
+			assert.Equal(t, victims, evicted)
+		case 2, 3, 4:
+			_, ok := c.Get(key)
+			s, exists := model[key]
+			assert.Equal(t, exists, ok)
+			if exists {
+				model[key] = state{freq: s.freq + 1, tick: i}
+			}
+		default:
+			s, exists := model[key]
+			victim := -1
+			if !exists && len(model) >= c.Capacity() {
+				victim = expectedVictim()
+				delete(model, victim)
+			}
+			evicted = []int{}
+			assert.Equal(t, victim >= 0, c.Add(key, i))
+			if victim >= 0 {
+				assert.Equal(t, []int{victim}, evicted)
+			}
+			model[key] = state{freq: s.freq + 1, tick: i}
+		}
+
+		assert.Equal(t, len(model), c.Len())
+		for key, s := range model {
+			assert.Equal(t, s.freq, c.Frequency(key))
+		}
+	}
+}
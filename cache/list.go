@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"github.com/jkratz55/collections-go/internal"
+)
+
+// keyedList is a KeyList with an index of its elements by key, allowing entries
+// to be looked up, moved and removed in O(1). The front of the list holds the
+// oldest entry and the back the newest.
+type keyedList[K comparable, V any] struct {
+	list  internal.KeyList[K, V]
+	index map[K]*internal.Element[K, V]
+}
+
+func newKeyedList[K comparable, V any]() *keyedList[K, V] {
+	return &keyedList[K, V]{
+		list:  internal.KeyList[K, V]{},
+		index: make(map[K]*internal.Element[K, V]),
+	}
+}
+
+func (l *keyedList[K, V]) len() int {
+	return len(l.index)
+}
+
+func (l *keyedList[K, V]) get(key K) (*internal.Element[K, V], bool) {
+	elem, ok := l.index[key]
+	return elem, ok
+}
+
+func (l *keyedList[K, V]) contains(key K) bool {
+	_, ok := l.index[key]
+	return ok
+}
+
+// pushBack adds a new entry at the back of the list. The key must not already
+// exist in the list.
+func (l *keyedList[K, V]) pushBack(key K, val V) {
+	l.index[key] = l.list.PushBack(key, val)
+}
+
+func (l *keyedList[K, V]) moveToBack(elem *internal.Element[K, V]) {
+	l.list.MoveToBack(elem)
+}
+
+func (l *keyedList[K, V]) remove(key K) (V, bool) {
+	elem, ok := l.index[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	l.list.Remove(elem)
+	delete(l.index, key)
+	return elem.Value, true
+}
+
+func (l *keyedList[K, V]) removeOldest() (K, V, bool) {
+	elem := l.list.Front()
+	if elem == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	l.list.Remove(elem)
+	delete(l.index, elem.Key)
+	return elem.Key, elem.Value, true
+}
+
+// keys returns the keys ordered from oldest to newest.
+func (l *keyedList[K, V]) keys() []K {
+	keys := make([]K, 0, len(l.index))
+	for e := l.list.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Key)
+	}
+	return keys
+}
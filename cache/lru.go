@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"sync"
+)
+
+// LRU is a thread safe fixed capacity cache that evicts the least recently used
+// entry when a new entry is added to a full cache. Get, Add, Peek and Remove are
+// all O(1) operations.
+//
+// The zero-value of LRU is not usable. NewLRU should be used to create and
+// initialize a new instance of LRU.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	// items orders the entries from least recently used at the front to most
+	// recently used at the back.
+	items   *keyedList[K, V]
+	onEvict EvictFunc[K, V]
+	stats   Stats
+}
+
+// NewLRU creates and initializes a new LRU with the given capacity. The capacity
+// must be greater than or equal to 1 otherwise NewLRU will panic. The optional
+// EvictFunc is invoked for each entry evicted from the cache, a nil EvictFunc is
+// allowed.
+func NewLRU[K comparable, V any](capacity int, onEvict EvictFunc[K, V]) *LRU[K, V] {
+	if capacity < 1 {
+		panic("capacity cannot be less than 1")
+	}
+	return &LRU[K, V]{
+		capacity: capacity,
+		items:    newKeyedList[K, V](),
+		onEvict:  onEvict,
+	}
+}
+
+// Get retrieves the value for a key and marks the entry as the most recently
+// used. If the key doesn't exist the zero value and false are returned.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items.get(key)
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.items.moveToBack(elem)
+	return elem.Value, true
+}
+
+// Peek retrieves the value for a key without marking the entry as recently used
+// or affecting the hit/miss stats.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items.get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return elem.Value, true
+}
+
+// Contains returns true if the key exists in the cache without marking the entry
+// as recently used.
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.contains(key)
+}
+
+// Add inserts or updates the value for a key and marks the entry as the most
+// recently used. If adding a new entry exceeds the capacity the least recently
+// used entry is evicted. Add returns true if an entry was evicted.
+func (c *LRU[K, V]) Add(key K, val V) bool {
+	c.mu.Lock()
+	if elem, ok := c.items.get(key); ok {
+		elem.Value = val
+		c.items.moveToBack(elem)
+		c.mu.Unlock()
+		return false
+	}
+	c.items.pushBack(key, val)
+	evicted := c.evict(c.capacity)
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, evicted)
+	return len(evicted) > 0
+}
+
+// Remove removes the entry for a key from the cache returning true if the key
+// existed. The EvictFunc is not invoked for removed entries.
+func (c *LRU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.items.remove(key)
+	return ok
+}
+
+// Resize changes the capacity of the cache evicting the least recently used
+// entries if the cache contains more entries than the new capacity. Resize
+// returns the number of entries evicted. The capacity must be greater than or
+// equal to 1 otherwise Resize will panic.
+func (c *LRU[K, V]) Resize(capacity int) int {
+	if capacity < 1 {
+		panic("capacity cannot be less than 1")
+	}
+	c.mu.Lock()
+	c.capacity = capacity
+	evicted := c.evict(capacity)
+	c.mu.Unlock()
+
+	notifyEvicted(c.onEvict, evicted)
+	return len(evicted)
+}
+
+// Purge removes all the entries from the cache. The EvictFunc is not invoked for
+// purged entries.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = newKeyedList[K, V]()
+}
+
+// Keys returns the keys in the cache ordered from least recently used to most
+// recently used.
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.keys()
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.len()
+}
+
+// Capacity returns the capacity of the cache.
+func (c *LRU[K, V]) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.capacity
+}
+
+// Stats returns a copy of the hit, miss and eviction counters of the cache.
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// evict removes the least recently used entries until the cache holds no more
+// than size entries and returns the evicted entries. The caller must hold the
+// lock.
+func (c *LRU[K, V]) evict(size int) []entry[K, V] {
+	var evicted []entry[K, V]
+	for c.items.len() > size {
+		key, val, _ := c.items.removeOldest()
+		c.stats.Evictions++
+		evicted = append(evicted, entry[K, V]{key: key, val: val})
+	}
+	return evicted
+}
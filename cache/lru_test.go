@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLRU(t *testing.T) {
+	assert.NotPanics(t, func() {
+		c := NewLRU[string, int](10, nil)
+		assert.Equal(t, 10, c.Capacity())
+		assert.Equal(t, 0, c.Len())
+	})
+
+	assert.Panics(t, func() {
+		_ = NewLRU[string, int](0, nil)
+	})
+}
+
+func TestLRU_Add(t *testing.T) {
+	var evicted []string
+	c := NewLRU[string, int](3, func(key string, val int) {
+		evicted = append(evicted, key)
+	})
+
+	assert.False(t, c.Add("one", 1))
+	assert.False(t, c.Add("two", 2))
+	assert.False(t, c.Add("three", 3))
+	assert.False(t, c.Add("one", 11))
+	assert.Equal(t, []string{"two", "three", "one"}, c.Keys())
+
+	assert.True(t, c.Add("four", 4))
+	assert.Equal(t, []string{"two"}, evicted)
+	assert.Equal(t, []string{"three", "one", "four"}, c.Keys())
+
+	val, ok := c.Peek("one")
+	assert.True(t, ok)
+	assert.Equal(t, 11, val)
+}
+
+func TestLRU_Get(t *testing.T) {
+	c := NewLRU[string, int](3, nil)
+	c.Add("one", 1)
+	c.Add("two", 2)
+	c.Add("three", 3)
+
+	val, ok := c.Get("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	_, ok = c.Get("missing")
+	assert.False(t, ok)
+
+	c.Add("four", 4)
+	assert.False(t, c.Contains("two"))
+	assert.True(t, c.Contains("one"))
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, Evictions: 1}, c.Stats())
+	assert.Equal(t, 0.5, c.Stats().HitRatio())
+}
+
+func TestLRU_Peek(t *testing.T) {
+	c := NewLRU[string, int](2, nil)
+	c.Add("one", 1)
+	c.Add("two", 2)
+
+	val, ok := c.Peek("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	// Peek doesn't promote the entry so it is still the least recently used
+	c.Add("three", 3)
+	assert.False(t, c.Contains("one"))
+	assert.Equal(t, Stats{Evictions: 1}, c.Stats())
+}
+
+func TestLRU_Remove(t *testing.T) {
+	evictions := 0
+	c := NewLRU[string, int](2, func(key string, val int) {
+		evictions++
+	})
+	c.Add("one", 1)
+	c.Add("two", 2)
+
+	assert.True(t, c.Remove("one"))
+	assert.False(t, c.Remove("one"))
+	assert.Equal(t, []string{"two"}, c.Keys())
+	assert.Equal(t, 0, evictions)
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, 0, evictions)
+}
+
+func TestLRU_Resize(t *testing.T) {
+	var evicted []int
+	c := NewLRU[int, int](5, func(key int, val int) {
+		evicted = append(evicted, key)
+	})
+	for i := 0; i < 5; i++ {
+		c.Add(i, i)
+	}
+
+	assert.Equal(t, 3, c.Resize(2))
+	assert.Equal(t, []int{0, 1, 2}, evicted)
+	assert.Equal(t, []int{3, 4}, c.Keys())
+
+	assert.Equal(t, 0, c.Resize(10))
+	assert.Equal(t, 10, c.Capacity())
+}
+
+func TestLRU_Concurrent(t *testing.T) {
+	var c *LRU[string, int]
+	c = NewLRU[string, int](50, func(key string, val int) {
+		// The callback may safely access the cache
+		_ = c.Len()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("%d-%d", n, j)
+				c.Add(key, j)
+				c.Get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 50, c.Len())
+}
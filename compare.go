@@ -0,0 +1,41 @@
+package collections
+
+// Ordered is a constraint that permits any type supporting the ordering operators
+// < <= >= >. This mirrors the Ordered constraint from the standard library cmp
+// package which isn't available to the version of Go this module targets.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// Compare returns -1 if a is less than b, 0 if a equals b and +1 if a is greater
+// than b. Floating point NaN values are considered less than any non-NaN value
+// and equal to each other, so Compare is a valid comparator for sorted
+// collections.
+func Compare[T Ordered](a, b T) int {
+	aNaN := isNaN(a)
+	bNaN := isNaN(b)
+	if aNaN {
+		if bNaN {
+			return 0
+		}
+		return -1
+	}
+	if bNaN {
+		return 1
+	}
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// isNaN reports whether x is a NaN without requiring a float type.
+func isNaN[T Ordered](x T) bool {
+	return x != x
+}
@@ -0,0 +1,20 @@
+package collections
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	assert.Equal(t, -1, Compare(1, 2))
+	assert.Equal(t, 0, Compare(2, 2))
+	assert.Equal(t, 1, Compare(3, 2))
+	assert.Equal(t, -1, Compare("a", "b"))
+
+	nan := math.NaN()
+	assert.Equal(t, -1, Compare(nan, math.Inf(-1)))
+	assert.Equal(t, 1, Compare(0.0, nan))
+	assert.Equal(t, 0, Compare(nan, nan))
+}
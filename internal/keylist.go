@@ -50,29 +50,117 @@ func (l *KeyList[K, V]) Remove(e *Element[K, V]) {
 
 func (l *KeyList[K, V]) PushFront(key K, val V) *Element[K, V] {
 	e := &Element[K, V]{Key: key, Value: val}
+	l.insertFront(e)
+	return e
+}
+
+func (l *KeyList[K, V]) PushBack(key K, val V) *Element[K, V] {
+	e := &Element[K, V]{Key: key, Value: val}
+	l.insertBack(e)
+	return e
+}
+
+// InsertBefore inserts a new element directly before mark, which must already be
+// in the list, and returns the new element.
+func (l *KeyList[K, V]) InsertBefore(key K, val V, mark *Element[K, V]) *Element[K, V] {
+	e := &Element[K, V]{Key: key, Value: val}
+	l.insertBefore(e, mark)
+	return e
+}
+
+// InsertAfter inserts a new element directly after mark, which must already be
+// in the list, and returns the new element.
+func (l *KeyList[K, V]) InsertAfter(key K, val V, mark *Element[K, V]) *Element[K, V] {
+	e := &Element[K, V]{Key: key, Value: val}
+	l.insertAfter(e, mark)
+	return e
+}
+
+// MoveToFront moves the element, which must already be in the list, to the front
+// of the list.
+func (l *KeyList[K, V]) MoveToFront(e *Element[K, V]) {
+	if l.root.next == e {
+		return
+	}
+	l.Remove(e)
+	l.insertFront(e)
+}
+
+// MoveToBack moves the element, which must already be in the list, to the back of
+// the list.
+func (l *KeyList[K, V]) MoveToBack(e *Element[K, V]) {
+	if l.root.prev == e {
+		return
+	}
+	l.Remove(e)
+	l.insertBack(e)
+}
+
+// MoveBefore moves the element to its new position before mark. Both elements
+// must already be in the list. If e and mark are the same element the list is
+// not modified.
+func (l *KeyList[K, V]) MoveBefore(e, mark *Element[K, V]) {
+	if e == mark || mark.prev == e {
+		return
+	}
+	l.Remove(e)
+	l.insertBefore(e, mark)
+}
+
+// MoveAfter moves the element to its new position after mark. Both elements must
+// already be in the list. If e and mark are the same element the list is not
+// modified.
+func (l *KeyList[K, V]) MoveAfter(e, mark *Element[K, V]) {
+	if e == mark || mark.next == e {
+		return
+	}
+	l.Remove(e)
+	l.insertAfter(e, mark)
+}
+
+func (l *KeyList[K, V]) insertBefore(e, mark *Element[K, V]) {
+	e.next = mark
+	e.prev = mark.prev
+	if mark.prev == nil {
+		l.root.next = e
+	} else {
+		mark.prev.next = e
+	}
+	mark.prev = e
+}
+
+func (l *KeyList[K, V]) insertAfter(e, mark *Element[K, V]) {
+	e.prev = mark
+	e.next = mark.next
+	if mark.next == nil {
+		l.root.prev = e
+	} else {
+		mark.next.prev = e
+	}
+	mark.next = e
+}
+
+func (l *KeyList[K, V]) insertFront(e *Element[K, V]) {
 	if l.root.next == nil {
 		l.root.next = e
 		l.root.prev = e
-		return e
+		return
 	}
 
 	e.next = l.root.next
 	l.root.next.prev = e
 	l.root.next = e
-	return e
 }
 
-func (l *KeyList[K, V]) PushBack(key K, val V) *Element[K, V] {
-	e := &Element[K, V]{Key: key, Value: val}
+func (l *KeyList[K, V]) insertBack(e *Element[K, V]) {
 	if l.root.prev == nil {
 		// It's the first element
 		l.root.next = e
 		l.root.prev = e
-		return e
+		return
 	}
 
 	e.prev = l.root.prev
 	l.root.prev.next = e
 	l.root.prev = e
-	return e
 }
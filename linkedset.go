@@ -0,0 +1,356 @@
+package collections
+
+import (
+	"encoding/json"
+
+	"github.com/jkratz55/collections-go/internal"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// LinkedSet is a Set which maintains the order elements were inserted into the
+// set, similar to Java's LinkedHashSet. Add, Remove and Contains remain O(1) and
+// the elements are iterated in insertion order. Adding an element which already
+// exists doesn't change its position.
+//
+// Because the iteration order is deterministic LinkedSet marshals to the same JSON
+// and msgpack for the same sequence of insertions, which makes it suitable for
+// golden files and cache keys.
+//
+// The zero-value of LinkedSet is not usable. NewLinkedSet should be used to create
+// and initialize a new instance of LinkedSet.
+//
+// Note: LinkedSet is not thread safe.
+type LinkedSet[T comparable] struct {
+	keys internal.KeyList[T, struct{}]
+	data map[T]*internal.Element[T, struct{}]
+}
+
+// NewLinkedSet creates and initializes a new LinkedSet
+func NewLinkedSet[T comparable]() *LinkedSet[T] {
+	return &LinkedSet[T]{
+		keys: internal.KeyList[T, struct{}]{},
+		data: make(map[T]*internal.Element[T, struct{}]),
+	}
+}
+
+// Add adds the elements to the end of the LinkedSet. If an element already exists
+// it is effectively a no-op and the element retains its position.
+func (s *LinkedSet[T]) Add(vals ...T) {
+	for _, val := range vals {
+		if _, exists := s.data[val]; !exists {
+			s.data[val] = s.keys.PushBack(val, struct{}{})
+		}
+	}
+}
+
+// AddAll adds all the elements of the provided Iterable to the end of the
+// LinkedSet in the order they are iterated.
+func (s *LinkedSet[T]) AddAll(vals Iterable[T]) {
+	vals.ForEach(func(val T) {
+		s.Add(val)
+	})
+}
+
+// Remove removes/deletes an element from the LinkedSet. If the element doesn't
+// exist this is a no-op.
+func (s *LinkedSet[T]) Remove(val T) {
+	if elem, exists := s.data[val]; exists {
+		s.keys.Remove(elem)
+		delete(s.data, val)
+	}
+}
+
+// Contains returns a boolean value indicating if the provided value is in the
+// LinkedSet.
+func (s *LinkedSet[T]) Contains(val T) bool {
+	_, ok := s.data[val]
+	return ok
+}
+
+// Size returns the current number of elements in the LinkedSet.
+func (s *LinkedSet[T]) Size() int {
+	return len(s.data)
+}
+
+// First returns the oldest element. If the LinkedSet is empty the zero value and
+// false are returned.
+func (s *LinkedSet[T]) First() (T, bool) {
+	return linkedSetValue(s.keys.Front())
+}
+
+// Last returns the newest element. If the LinkedSet is empty the zero value and
+// false are returned.
+func (s *LinkedSet[T]) Last() (T, bool) {
+	return linkedSetValue(s.keys.Back())
+}
+
+// Pop removes and returns the oldest element. If the LinkedSet is empty the zero
+// value and false are returned.
+func (s *LinkedSet[T]) Pop() (T, bool) {
+	val, ok := s.First()
+	if ok {
+		s.Remove(val)
+	}
+	return val, ok
+}
+
+// Clear removes all the elements from the LinkedSet.
+func (s *LinkedSet[T]) Clear() {
+	s.keys = internal.KeyList[T, struct{}]{}
+	s.data = make(map[T]*internal.Element[T, struct{}])
+}
+
+// Equals returns a boolean indicating if the LinkedSet contains the same elements
+// as the provided LinkedSet. Like Set equality the order of the elements is not
+// considered.
+func (s *LinkedSet[T]) Equals(other *LinkedSet[T]) bool {
+	if len(s.data) != len(other.data) {
+		return false
+	}
+	for key := range s.data {
+		if _, found := other.data[key]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ForEach iterates through the LinkedSet in insertion order passing the value to
+// the provided function.
+func (s *LinkedSet[T]) ForEach(fn func(val T)) {
+	for e := s.keys.Front(); e != nil; e = e.Next() {
+		fn(e.Key)
+	}
+}
+
+// Iter returns a stateful iterator for iterating over a LinkedSet in insertion
+// order.
+//
+// Note: Internally AsSlice is called to populate the SetIterator, so the LinkedSet
+// may be modified while iterating.
+func (s *LinkedSet[T]) Iter() *SetIterator[T] {
+	return &SetIterator[T]{
+		current: -1,
+		data:    s.AsSlice(),
+	}
+}
+
+// AsSlice returns the elements of the LinkedSet as a slice in insertion order.
+func (s *LinkedSet[T]) AsSlice() []T {
+	vals := make([]T, 0, len(s.data))
+	s.ForEach(func(val T) {
+		vals = append(vals, val)
+	})
+	return vals
+}
+
+// Clone returns a new LinkedSet with the same elements in the same order.
+func (s *LinkedSet[T]) Clone() *LinkedSet[T] {
+	return s.Filter(func(val T) bool {
+		return true
+	})
+}
+
+// Union returns a new LinkedSet containing the elements of this LinkedSet followed
+// by the elements of other which are not in this LinkedSet.
+func (s *LinkedSet[T]) Union(other *LinkedSet[T]) *LinkedSet[T] {
+	newSet := s.Clone()
+	newSet.UnionInPlace(other)
+	return newSet
+}
+
+// Subtract returns a new LinkedSet containing the elements in this LinkedSet that
+// are not in other, the set difference s \ other, in the order of this LinkedSet.
+func (s *LinkedSet[T]) Subtract(other *LinkedSet[T]) *LinkedSet[T] {
+	return s.Filter(func(val T) bool {
+		return !other.Contains(val)
+	})
+}
+
+// Intersection returns a new LinkedSet containing the elements in both this
+// LinkedSet and other, in the order of this LinkedSet.
+func (s *LinkedSet[T]) Intersection(other *LinkedSet[T]) *LinkedSet[T] {
+	return s.Filter(other.Contains)
+}
+
+// SymmetricDifference returns a new LinkedSet containing the elements which are in
+// either this LinkedSet or other but not in both. The elements of this LinkedSet
+// come first followed by the elements of other.
+func (s *LinkedSet[T]) SymmetricDifference(other *LinkedSet[T]) *LinkedSet[T] {
+	newSet := s.Subtract(other)
+	other.ForEach(func(val T) {
+		if !s.Contains(val) {
+			newSet.Add(val)
+		}
+	})
+	return newSet
+}
+
+// IsSubsetOf returns true if every element of this LinkedSet is in other.
+func (s *LinkedSet[T]) IsSubsetOf(other *LinkedSet[T]) bool {
+	if len(s.data) > len(other.data) {
+		return false
+	}
+	for key := range s.data {
+		if _, found := other.data[key]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf returns true if every element of other is in this LinkedSet.
+func (s *LinkedSet[T]) IsSupersetOf(other *LinkedSet[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// IsDisjoint returns true if this LinkedSet and other have no elements in common.
+// The smaller of the two sets is iterated.
+func (s *LinkedSet[T]) IsDisjoint(other *LinkedSet[T]) bool {
+	smaller, larger := s, other
+	if len(smaller.data) > len(larger.data) {
+		smaller, larger = larger, smaller
+	}
+	for key := range smaller.data {
+		if _, found := larger.data[key]; found {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionInPlace adds the elements of other which are not in this LinkedSet to the
+// end of this LinkedSet, in the order of other.
+func (s *LinkedSet[T]) UnionInPlace(other *LinkedSet[T]) {
+	other.ForEach(func(val T) {
+		s.Add(val)
+	})
+}
+
+// RetainAll removes the elements from this LinkedSet that are not in other,
+// leaving the intersection of both sets.
+func (s *LinkedSet[T]) RetainAll(other *LinkedSet[T]) {
+	s.removeIf(func(val T) bool {
+		return !other.Contains(val)
+	})
+}
+
+// RemoveAll removes the elements from this LinkedSet that are in other, leaving
+// the set difference s \ other.
+func (s *LinkedSet[T]) RemoveAll(other *LinkedSet[T]) {
+	s.removeIf(other.Contains)
+}
+
+// Filter returns a new LinkedSet containing the elements for which the predicate
+// returns true, in the same order.
+func (s *LinkedSet[T]) Filter(pred func(val T) bool) *LinkedSet[T] {
+	newSet := NewLinkedSet[T]()
+	for e := s.keys.Front(); e != nil; e = e.Next() {
+		if pred(e.Key) {
+			newSet.data[e.Key] = newSet.keys.PushBack(e.Key, struct{}{})
+		}
+	}
+	return newSet
+}
+
+// Any returns true if the predicate returns true for at least one element of the
+// LinkedSet. Any returns false for an empty LinkedSet.
+func (s *LinkedSet[T]) Any(pred func(val T) bool) bool {
+	for e := s.keys.Front(); e != nil; e = e.Next() {
+		if pred(e.Key) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true if the predicate returns true for every element of the
+// LinkedSet. All returns true for an empty LinkedSet.
+func (s *LinkedSet[T]) All(pred func(val T) bool) bool {
+	for e := s.keys.Front(); e != nil; e = e.Next() {
+		if !pred(e.Key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Partition returns two new LinkedSets, the first containing the elements for
+// which the predicate returns true and the second containing the rest. Both keep
+// the order of this LinkedSet.
+func (s *LinkedSet[T]) Partition(pred func(val T) bool) (*LinkedSet[T], *LinkedSet[T]) {
+	matched, rest := NewLinkedSet[T](), NewLinkedSet[T]()
+	for e := s.keys.Front(); e != nil; e = e.Next() {
+		if pred(e.Key) {
+			matched.data[e.Key] = matched.keys.PushBack(e.Key, struct{}{})
+		} else {
+			rest.data[e.Key] = rest.keys.PushBack(e.Key, struct{}{})
+		}
+	}
+	return matched, rest
+}
+
+// MarshalJSON marshals a LinkedSet into binary JSON representation as a JSON
+// array in insertion order.
+func (s *LinkedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.AsSlice())
+}
+
+// UnmarshalJSON unmarshalls binary JSON representation of a LinkedSet into this
+// instance of LinkedSet. The elements are added in the order they appear in the
+// JSON array.
+func (s *LinkedSet[T]) UnmarshalJSON(data []byte) error {
+	var raw []T
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.lazyInit()
+	s.Add(raw...)
+	return nil
+}
+
+// MarshalMsgpack marshals a LinkedSet into binary msgpack representation as a
+// msgpack array in insertion order.
+func (s *LinkedSet[T]) MarshalMsgpack() ([]byte, error) {
+	return msgpack.Marshal(s.AsSlice())
+}
+
+// UnmarshalMsgpack unmarshalls binary msgpack representation of a LinkedSet into
+// this instance of LinkedSet. The elements are added in the order they appear in
+// the msgpack array.
+func (s *LinkedSet[T]) UnmarshalMsgpack(data []byte) error {
+	var raw []T
+	if err := msgpack.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.lazyInit()
+	s.Add(raw...)
+	return nil
+}
+
+// lazyInit initializes the internal state of a zero-value LinkedSet so that it can
+// be used as the target for unmarshalling.
+func (s *LinkedSet[T]) lazyInit() {
+	if s.data == nil {
+		s.data = make(map[T]*internal.Element[T, struct{}])
+	}
+}
+
+func (s *LinkedSet[T]) removeIf(pred func(val T) bool) {
+	for e := s.keys.Front(); e != nil; {
+		next := e.Next()
+		if pred(e.Key) {
+			s.keys.Remove(e)
+			delete(s.data, e.Key)
+		}
+		e = next
+	}
+}
+
+func linkedSetValue[T comparable](elem *internal.Element[T, struct{}]) (T, bool) {
+	if elem == nil {
+		var zero T
+		return zero, false
+	}
+	return elem.Key, true
+}
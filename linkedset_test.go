@@ -0,0 +1,147 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func newLinkedSetOf[T comparable](vals ...T) *LinkedSet[T] {
+	s := NewLinkedSet[T]()
+	s.Add(vals...)
+	return s
+}
+
+func TestLinkedSet(t *testing.T) {
+	s := NewLinkedSet[string]()
+	s.Add("pizza", "tacos", "hamburger", "pizza")
+
+	assert.Equal(t, 3, s.Size())
+	assert.Equal(t, []string{"pizza", "tacos", "hamburger"}, s.AsSlice())
+	assert.True(t, s.Contains("tacos"))
+
+	s.Remove("tacos")
+	s.Remove("pasta")
+	assert.False(t, s.Contains("tacos"))
+	assert.Equal(t, []string{"pizza", "hamburger"}, s.AsSlice())
+
+	s.Add("tacos")
+	assert.Equal(t, []string{"pizza", "hamburger", "tacos"}, s.AsSlice())
+
+	var actual []string
+	for iter := s.Iter(); iter.Next(); {
+		actual = append(actual, iter.Value())
+	}
+	assert.Equal(t, s.AsSlice(), actual)
+}
+
+func TestLinkedSet_FirstLastPop(t *testing.T) {
+	s := NewLinkedSet[int]()
+	_, ok := s.First()
+	assert.False(t, ok)
+	_, ok = s.Pop()
+	assert.False(t, ok)
+
+	s.Add(3, 1, 2)
+	first, _ := s.First()
+	last, _ := s.Last()
+	assert.Equal(t, 3, first)
+	assert.Equal(t, 2, last)
+
+	val, ok := s.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+	assert.Equal(t, []int{1, 2}, s.AsSlice())
+
+	s.Clear()
+	assert.Equal(t, 0, s.Size())
+	s.Add(5)
+	assert.Equal(t, []int{5}, s.AsSlice())
+}
+
+func TestLinkedSet_Equals(t *testing.T) {
+	s1 := newLinkedSetOf(1, 2, 3)
+	assert.True(t, s1.Equals(newLinkedSetOf(3, 2, 1)))
+	assert.False(t, s1.Equals(newLinkedSetOf(1, 2)))
+	assert.False(t, s1.Equals(newLinkedSetOf(1, 2, 4)))
+}
+
+func TestLinkedSet_Clone(t *testing.T) {
+	s1 := newLinkedSetOf("b", "a", "c")
+	s2 := s1.Clone()
+	assert.Equal(t, s1.AsSlice(), s2.AsSlice())
+
+	s2.Add("d")
+	assert.False(t, s1.Contains("d"))
+}
+
+func TestLinkedSet_Algebra(t *testing.T) {
+	s1 := newLinkedSetOf(4, 1, 3, 2)
+	s2 := newLinkedSetOf(5, 3, 4)
+
+	assert.Equal(t, []int{4, 1, 3, 2, 5}, s1.Union(s2).AsSlice())
+	assert.Equal(t, []int{4, 3}, s1.Intersection(s2).AsSlice())
+	assert.Equal(t, []int{3, 4}, s2.Intersection(s1).AsSlice())
+	assert.Equal(t, []int{1, 2}, s1.Subtract(s2).AsSlice())
+	assert.Equal(t, []int{1, 2, 5}, s1.SymmetricDifference(s2).AsSlice())
+
+	assert.True(t, newLinkedSetOf(3, 4).IsSubsetOf(s1))
+	assert.False(t, s2.IsSubsetOf(s1))
+	assert.True(t, s1.IsSupersetOf(newLinkedSetOf(2)))
+	assert.True(t, s1.IsDisjoint(newLinkedSetOf(7, 8)))
+	assert.False(t, s1.IsDisjoint(s2))
+}
+
+func TestLinkedSet_InPlace(t *testing.T) {
+	s := newLinkedSetOf(1, 2, 3)
+	s.UnionInPlace(newLinkedSetOf(5, 2, 4))
+	assert.Equal(t, []int{1, 2, 3, 5, 4}, s.AsSlice())
+
+	s.RetainAll(newLinkedSetOf(4, 3, 2))
+	assert.Equal(t, []int{2, 3, 4}, s.AsSlice())
+
+	s.RemoveAll(newLinkedSetOf(3))
+	assert.Equal(t, []int{2, 4}, s.AsSlice())
+
+	s.AddAll(newLinkedSetOf(9, 8))
+	assert.Equal(t, []int{2, 4, 9, 8}, s.AsSlice())
+}
+
+func TestLinkedSet_Predicates(t *testing.T) {
+	s := newLinkedSetOf(5, 4, 3, 2, 1)
+	isEven := func(val int) bool { return val%2 == 0 }
+
+	assert.Equal(t, []int{4, 2}, s.Filter(isEven).AsSlice())
+	assert.True(t, s.Any(isEven))
+	assert.False(t, s.All(isEven))
+
+	even, odd := s.Partition(isEven)
+	assert.Equal(t, []int{4, 2}, even.AsSlice())
+	assert.Equal(t, []int{5, 3, 1}, odd.AsSlice())
+}
+
+func TestLinkedSet_JSON(t *testing.T) {
+	s := newLinkedSetOf("zebra", "apple", "mango")
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["zebra","apple","mango"]`, string(data))
+
+	var decoded LinkedSet[string]
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []string{"zebra", "apple", "mango"}, decoded.AsSlice())
+}
+
+func TestLinkedSet_Msgpack(t *testing.T) {
+	s := newLinkedSetOf(3, 1, 2)
+	data, err := msgpack.Marshal(s)
+	assert.NoError(t, err)
+
+	expected, _ := msgpack.Marshal([]int{3, 1, 2})
+	assert.Equal(t, expected, data)
+
+	var decoded LinkedSet[int]
+	assert.NoError(t, msgpack.Unmarshal(data, &decoded))
+	assert.Equal(t, []int{3, 1, 2}, decoded.AsSlice())
+}
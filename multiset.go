@@ -0,0 +1,303 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MultiSet, also known as a bag, is a collection which tracks how many times each
+// element occurs. Unlike Set an element can be added more than once, and the
+// number of occurrences of an element is its count.
+//
+// MultiSet supports marshaling/unmarshalling for json and msgpack as an object
+// mapping each element to its count, for example {"a": 2, "b": 1}. JSON object
+// keys must be strings so the elements are converted following the same rules
+// encoding/json uses for map keys.
+//
+// The zero-value of MultiSet is not usable. NewMultiSet should be used to create
+// and initialize a new instance of MultiSet.
+//
+// Note: MultiSet is not thread safe.
+type MultiSet[T comparable] struct {
+	data  map[T]int
+	total int
+}
+
+// ElementCount is an element of a MultiSet along with its count.
+type ElementCount[T comparable] struct {
+	Element T
+	Count   int
+}
+
+// NewMultiSet creates and initializes a new MultiSet
+func NewMultiSet[T comparable]() *MultiSet[T] {
+	return &MultiSet[T]{
+		data: make(map[T]int),
+	}
+}
+
+// Add adds n occurrences of the element to the MultiSet. Adding zero occurrences
+// is a no-op. The count cannot be negative otherwise Add will panic, use Remove to
+// remove occurrences.
+func (m *MultiSet[T]) Add(val T, n int) {
+	if n < 0 {
+		panic("count cannot be negative")
+	}
+	if n == 0 {
+		return
+	}
+	m.data[val] += n
+	m.total += n
+}
+
+// Remove removes up to n occurrences of the element from the MultiSet and returns
+// the number of occurrences removed. Once the count of an element reaches zero the
+// element no longer exists in the MultiSet. The count cannot be negative otherwise
+// Remove will panic.
+func (m *MultiSet[T]) Remove(val T, n int) int {
+	if n < 0 {
+		panic("count cannot be negative")
+	}
+	count := m.data[val]
+	if n >= count {
+		delete(m.data, val)
+		m.total -= count
+		return count
+	}
+	m.data[val] = count - n
+	m.total -= n
+	return n
+}
+
+// RemoveAll removes all the occurrences of the element from the MultiSet and
+// returns the number of occurrences removed.
+func (m *MultiSet[T]) RemoveAll(val T) int {
+	count := m.data[val]
+	delete(m.data, val)
+	m.total -= count
+	return count
+}
+
+// Count returns the number of occurrences of the element, or 0 if the element
+// doesn't exist in the MultiSet.
+func (m *MultiSet[T]) Count(val T) int {
+	return m.data[val]
+}
+
+// Contains returns a boolean value indicating if the element occurs at least once
+// in the MultiSet.
+func (m *MultiSet[T]) Contains(val T) bool {
+	_, ok := m.data[val]
+	return ok
+}
+
+// Size returns the number of distinct elements in the MultiSet.
+func (m *MultiSet[T]) Size() int {
+	return len(m.data)
+}
+
+// TotalSize returns the total number of occurrences of all the elements in the
+// MultiSet.
+func (m *MultiSet[T]) TotalSize() int {
+	return m.total
+}
+
+// Distinct returns a new Set containing the distinct elements of the MultiSet.
+func (m *MultiSet[T]) Distinct() *Set[T] {
+	set := NewSet[T]()
+	for key := range m.data {
+		set.data[key] = struct{}{}
+	}
+	return set
+}
+
+// MostCommon returns the k elements with the highest counts ordered from the
+// highest count to the lowest. Elements with equal counts are returned in an
+// arbitrary order. If k is negative or greater than the number of distinct
+// elements all the elements are returned.
+//
+// Only the k most common elements are kept in a PriorityQueue while counting, so
+// MostCommon is O(n log k) for n distinct elements.
+func (m *MultiSet[T]) MostCommon(k int) []ElementCount[T] {
+	if k < 0 || k >= len(m.data) {
+		all := make([]ElementCount[T], 0, len(m.data))
+		for key, count := range m.data {
+			all = append(all, ElementCount[T]{Element: key, Count: count})
+		}
+		sort.Slice(all, func(i, j int) bool {
+			return all[i].Count > all[j].Count
+		})
+		return all
+	}
+
+	// Keep the k most common elements seen so far. Prioritizing by the negated
+	// count makes Poll remove the least common of them once there are more than k.
+	pq := NewPriorityQueue[T]()
+	for key, count := range m.data {
+		pq.Push(key, -count)
+		if pq.Len() > k {
+			pq.Poll()
+		}
+	}
+	common := make([]ElementCount[T], pq.Len())
+	for i := len(common) - 1; i >= 0; i-- {
+		val, _ := pq.Poll()
+		common[i] = ElementCount[T]{Element: val, Count: m.data[val]}
+	}
+	return common
+}
+
+// Clear removes all the elements from the MultiSet.
+func (m *MultiSet[T]) Clear() {
+	m.data = make(map[T]int)
+	m.total = 0
+}
+
+// Equals returns a boolean indicating if the MultiSet contains the same elements
+// with the same counts as the provided MultiSet.
+func (m *MultiSet[T]) Equals(other *MultiSet[T]) bool {
+	if len(m.data) != len(other.data) || m.total != other.total {
+		return false
+	}
+	for key, count := range m.data {
+		if other.data[key] != count {
+			return false
+		}
+	}
+	return true
+}
+
+// ForEach iterates through the distinct elements of the MultiSet passing each
+// element and its count to the provided function.
+func (m *MultiSet[T]) ForEach(fn func(val T, count int)) {
+	for key, count := range m.data {
+		fn(key, count)
+	}
+}
+
+// Union returns a new MultiSet where the count of each element is the maximum of
+// its counts in this MultiSet and other.
+func (m *MultiSet[T]) Union(other *MultiSet[T]) *MultiSet[T] {
+	result := m.Clone()
+	for key, count := range other.data {
+		if count > result.data[key] {
+			result.Add(key, count-result.data[key])
+		}
+	}
+	return result
+}
+
+// Intersection returns a new MultiSet where the count of each element is the
+// minimum of its counts in this MultiSet and other. Elements which don't occur in
+// both are omitted.
+func (m *MultiSet[T]) Intersection(other *MultiSet[T]) *MultiSet[T] {
+	smaller, larger := m, other
+	if len(smaller.data) > len(larger.data) {
+		smaller, larger = larger, smaller
+	}
+	result := NewMultiSet[T]()
+	for key, count := range smaller.data {
+		if otherCount, ok := larger.data[key]; ok {
+			if otherCount < count {
+				count = otherCount
+			}
+			result.Add(key, count)
+		}
+	}
+	return result
+}
+
+// Sum returns a new MultiSet where the count of each element is the sum of its
+// counts in this MultiSet and other.
+func (m *MultiSet[T]) Sum(other *MultiSet[T]) *MultiSet[T] {
+	result := m.Clone()
+	for key, count := range other.data {
+		result.Add(key, count)
+	}
+	return result
+}
+
+// Clone returns a new MultiSet with the same elements and counts.
+func (m *MultiSet[T]) Clone() *MultiSet[T] {
+	other := NewMultiSet[T]()
+	for key, count := range m.data {
+		other.data[key] = count
+	}
+	other.total = m.total
+	return other
+}
+
+// MarshalJSON marshals a MultiSet into binary JSON representation as a JSON
+// object mapping each element to its count. The keys of the object are sorted.
+func (m *MultiSet[T]) MarshalJSON() ([]byte, error) {
+	counts := make(map[string]int, len(m.data))
+	for key, count := range m.data {
+		text, err := marshalKeyText(key)
+		if err != nil {
+			return nil, err
+		}
+		counts[text] = count
+	}
+	return json.Marshal(counts)
+}
+
+// UnmarshalJSON unmarshalls binary JSON representation of a MultiSet into this
+// instance of MultiSet. The counts are added to the existing counts.
+func (m *MultiSet[T]) UnmarshalJSON(data []byte) error {
+	var counts map[string]int
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return err
+	}
+	m.lazyInit()
+	for text, count := range counts {
+		key, err := unmarshalKeyText[T](text)
+		if err != nil {
+			return err
+		}
+		if err := m.addDecoded(key, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalMsgpack marshals a MultiSet into binary msgpack representation as a
+// msgpack map of each element to its count.
+func (m *MultiSet[T]) MarshalMsgpack() ([]byte, error) {
+	return msgpack.Marshal(m.data)
+}
+
+// UnmarshalMsgpack unmarshalls binary msgpack representation of a MultiSet into
+// this instance of MultiSet. The counts are added to the existing counts.
+func (m *MultiSet[T]) UnmarshalMsgpack(data []byte) error {
+	var counts map[T]int
+	if err := msgpack.Unmarshal(data, &counts); err != nil {
+		return err
+	}
+	m.lazyInit()
+	for key, count := range counts {
+		if err := m.addDecoded(key, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lazyInit initializes the internal state of a zero-value MultiSet so that it can
+// be used as the target for unmarshalling.
+func (m *MultiSet[T]) lazyInit() {
+	if m.data == nil {
+		m.data = make(map[T]int)
+	}
+}
+
+func (m *MultiSet[T]) addDecoded(key T, count int) error {
+	if count < 0 {
+		return fmt.Errorf("cannot unmarshal negative count %d into MultiSet", count)
+	}
+	m.Add(key, count)
+	return nil
+}
@@ -0,0 +1,166 @@
+package collections
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMultiSet(t *testing.T) {
+	ms := NewMultiSet[string]()
+	ms.Add("error", 3)
+	ms.Add("warn", 1)
+	ms.Add("error", 2)
+	ms.Add("info", 0)
+
+	assert.Equal(t, 5, ms.Count("error"))
+	assert.Equal(t, 1, ms.Count("warn"))
+	assert.Equal(t, 0, ms.Count("info"))
+	assert.False(t, ms.Contains("info"))
+	assert.Equal(t, 2, ms.Size())
+	assert.Equal(t, 6, ms.TotalSize())
+
+	assert.Equal(t, 2, ms.Remove("error", 2))
+	assert.Equal(t, 3, ms.Count("error"))
+	assert.Equal(t, 1, ms.Remove("warn", 5))
+	assert.False(t, ms.Contains("warn"))
+	assert.Equal(t, 0, ms.Remove("debug", 1))
+	assert.Equal(t, 3, ms.TotalSize())
+
+	ms.Add("warn", 2)
+	assert.Equal(t, 3, ms.RemoveAll("error"))
+	assert.Equal(t, 2, ms.TotalSize())
+	assert.True(t, ms.Distinct().Equals(setOf("warn")))
+
+	ms.Clear()
+	assert.Equal(t, 0, ms.Size())
+	assert.Equal(t, 0, ms.TotalSize())
+
+	assert.Panics(t, func() {
+		ms.Add("error", -1)
+	})
+	assert.Panics(t, func() {
+		ms.Remove("error", -1)
+	})
+}
+
+func TestMultiSet_MostCommon(t *testing.T) {
+	ms := NewMultiSet[string]()
+	ms.Add("a", 1)
+	ms.Add("b", 5)
+	ms.Add("c", 3)
+
+	assert.Equal(t, []ElementCount[string]{
+		{Element: "b", Count: 5},
+		{Element: "c", Count: 3},
+	}, ms.MostCommon(2))
+	assert.Len(t, ms.MostCommon(-1), 3)
+	assert.Len(t, ms.MostCommon(10), 3)
+	assert.Empty(t, ms.MostCommon(0))
+	assert.Empty(t, NewMultiSet[string]().MostCommon(3))
+	assert.Equal(t, []ElementCount[string]{
+		{Element: "b", Count: 5},
+		{Element: "c", Count: 3},
+		{Element: "a", Count: 1},
+	}, ms.MostCommon(-1))
+
+	// Compare the bounded heap against sorting all the elements
+	large := NewMultiSet[int]()
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		large.Add(i, 1+r.Intn(10000))
+	}
+	all := large.MostCommon(-1)
+	for _, k := range []int{1, 5, 50, 999} {
+		common := large.MostCommon(k)
+		assert.Len(t, common, k)
+		for i := range common {
+			assert.Equal(t, all[i].Count, common[i].Count)
+			assert.Equal(t, common[i].Count, large.Count(common[i].Element))
+		}
+	}
+}
+
+func TestMultiSet_Algebra(t *testing.T) {
+	m1 := NewMultiSet[string]()
+	m1.Add("a", 3)
+	m1.Add("b", 1)
+
+	m2 := NewMultiSet[string]()
+	m2.Add("a", 1)
+	m2.Add("b", 2)
+	m2.Add("c", 4)
+
+	union := m1.Union(m2)
+	assert.Equal(t, map[string]int{"a": 3, "b": 2, "c": 4}, union.data)
+	assert.Equal(t, 9, union.TotalSize())
+
+	intersection := m1.Intersection(m2)
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, intersection.data)
+	assert.Equal(t, 2, intersection.TotalSize())
+
+	sum := m1.Sum(m2)
+	assert.Equal(t, map[string]int{"a": 4, "b": 3, "c": 4}, sum.data)
+	assert.Equal(t, 11, sum.TotalSize())
+
+	// The operands are left unmodified
+	assert.Equal(t, map[string]int{"a": 3, "b": 1}, m1.data)
+	assert.True(t, m1.Equals(m1.Clone()))
+	assert.False(t, m1.Equals(m2))
+}
+
+func TestMultiSet_ForEach(t *testing.T) {
+	ms := NewMultiSet[int]()
+	ms.Add(1, 2)
+	ms.Add(2, 1)
+
+	counts := make(map[int]int)
+	ms.ForEach(func(val int, count int) {
+		counts[val] = count
+	})
+	assert.Equal(t, map[int]int{1: 2, 2: 1}, counts)
+}
+
+func TestMultiSet_JSON(t *testing.T) {
+	ms := NewMultiSet[string]()
+	ms.Add("warn", 1)
+	ms.Add("error", 2)
+
+	data, err := json.Marshal(ms)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"error":2,"warn":1}`, string(data))
+
+	var decoded MultiSet[string]
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, ms.Equals(&decoded))
+
+	ints := NewMultiSet[int]()
+	assert.NoError(t, json.Unmarshal([]byte(`{"404":3,"500":1}`), ints))
+	assert.Equal(t, 3, ints.Count(404))
+	assert.Equal(t, 4, ints.TotalSize())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"404":-1}`), ints))
+	assert.Error(t, json.Unmarshal([]byte(`{"abc":1}`), ints))
+}
+
+func TestMultiSet_Msgpack(t *testing.T) {
+	ms := NewMultiSet[int]()
+	ms.Add(404, 3)
+	ms.Add(500, 1)
+
+	data, err := msgpack.Marshal(ms)
+	assert.NoError(t, err)
+
+	var decoded MultiSet[int]
+	assert.NoError(t, msgpack.Unmarshal(data, &decoded))
+	assert.True(t, ms.Equals(&decoded))
+}
+
+func setOf[T comparable](vals ...T) *Set[T] {
+	s := NewSet[T]()
+	s.Add(vals...)
+	return s
+}
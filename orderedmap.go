@@ -9,11 +9,16 @@ import (
 // iterated in the order they were added using ForEach, or in reverse order using
 // ForEachReverse.
 //
+// OrderedMap can alternatively maintain access order, see NewAccessOrderedMap.
+// In either mode entries can be manually reordered using MoveToFront, MoveToBack,
+// MoveBefore and MoveAfter.
+//
 // The zero-value of OrderedMap is not usable. NewOrderedMap should be used to
 // create and initialize a new instance of OrderedMap.
 type OrderedMap[K comparable, V any] struct {
-	keys internal.KeyList[K, V]
-	data map[K]*internal.Element[K, V]
+	keys        internal.KeyList[K, V]
+	data        map[K]*internal.Element[K, V]
+	accessOrder bool
 }
 
 // NewOrderedMap creates and initializes a new OrderedMap
@@ -24,16 +29,53 @@ func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
 	}
 }
 
+// Pair is a single key/value pair.
+type Pair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// FromPairs creates and initializes a new OrderedMap containing the provided
+// key/value pairs in the order they were provided. If a key appears more than
+// once the last value wins while the key retains the position of its first
+// occurrence.
+func FromPairs[K comparable, V any](pairs ...Pair[K, V]) *OrderedMap[K, V] {
+	m := NewOrderedMap[K, V]()
+	for _, pair := range pairs {
+		m.Set(pair.Key, pair.Value)
+	}
+	return m
+}
+
+// NewAccessOrderedMap creates and initializes a new OrderedMap which maintains
+// access order rather than insertion order. Every time an entry is accessed by
+// Get, GetOrDefault or Set it is moved to the back of the map, so iterating with
+// ForEach goes from the least recently accessed entry to the most recently
+// accessed entry. Contains does not count as an access.
+//
+// This is similar to Java's LinkedHashMap with accessOrder set to true and is
+// useful for recency tracking.
+func NewAccessOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	m := NewOrderedMap[K, V]()
+	m.accessOrder = true
+	return m
+}
+
 // Set inserts a new key/value into the map or replaces the value for an existing
 // key. If the key didn't exist in the map and was inserted true is returned.
 // Otherwise, if they key was already existing returns false.
+//
+// Replacing the value of an existing key retains its position, unless the map
+// maintains access order in which case the entry is moved to the back.
 func (m *OrderedMap[K, V]) Set(key K, val V) bool {
-	if _, exists := m.data[key]; !exists {
+	elem, exists := m.data[key]
+	if !exists {
 		element := m.keys.PushBack(key, val)
 		m.data[key] = element
 		return true
 	}
-	m.data[key].Value = val
+	elem.Value = val
+	m.recordAccess(elem)
 	return false
 }
 
@@ -52,6 +94,7 @@ func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
 		var zero V
 		return zero, false
 	}
+	m.recordAccess(val)
 	return val.Value, true
 }
 
@@ -62,6 +105,7 @@ func (m *OrderedMap[K, V]) GetOrDefault(key K, defaultValue V) V {
 	if !exists {
 		return defaultValue
 	}
+	m.recordAccess(val)
 	return val.Value
 }
 
@@ -77,12 +121,181 @@ func (m *OrderedMap[K, V]) Delete(key K) bool {
 	return true
 }
 
+// MoveToFront moves the entry for a key to the front of the map. If the key
+// doesn't exist false is returned and the map is not modified.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	elem, exists := m.data[key]
+	if !exists {
+		return false
+	}
+	m.keys.MoveToFront(elem)
+	return true
+}
+
+// MoveToBack moves the entry for a key to the back of the map. If the key
+// doesn't exist false is returned and the map is not modified.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	elem, exists := m.data[key]
+	if !exists {
+		return false
+	}
+	m.keys.MoveToBack(elem)
+	return true
+}
+
+// MoveBefore moves the entry for a key so that it directly precedes the entry
+// for mark. If either key doesn't exist false is returned and the map is not
+// modified.
+func (m *OrderedMap[K, V]) MoveBefore(key K, mark K) bool {
+	elem, exists := m.data[key]
+	if !exists {
+		return false
+	}
+	markElem, exists := m.data[mark]
+	if !exists {
+		return false
+	}
+	m.keys.MoveBefore(elem, markElem)
+	return true
+}
+
+// MoveAfter moves the entry for a key so that it directly follows the entry for
+// mark. If either key doesn't exist false is returned and the map is not modified.
+func (m *OrderedMap[K, V]) MoveAfter(key K, mark K) bool {
+	elem, exists := m.data[key]
+	if !exists {
+		return false
+	}
+	markElem, exists := m.data[mark]
+	if !exists {
+		return false
+	}
+	m.keys.MoveAfter(elem, markElem)
+	return true
+}
+
+// First returns the key/value pair at the front of the map. If the map is empty
+// the zero values and false are returned.
+func (m *OrderedMap[K, V]) First() (K, V, bool) {
+	return entryOf(m.keys.Front())
+}
+
+// Last returns the key/value pair at the back of the map. If the map is empty the
+// zero values and false are returned.
+func (m *OrderedMap[K, V]) Last() (K, V, bool) {
+	return entryOf(m.keys.Back())
+}
+
+// PopFirst removes and returns the key/value pair at the front of the map. If the
+// map is empty the zero values and false are returned.
+func (m *OrderedMap[K, V]) PopFirst() (K, V, bool) {
+	return m.pop(m.keys.Front())
+}
+
+// PopLast removes and returns the key/value pair at the back of the map. If the
+// map is empty the zero values and false are returned.
+func (m *OrderedMap[K, V]) PopLast() (K, V, bool) {
+	return m.pop(m.keys.Back())
+}
+
+// Next returns the key/value pair that follows the given key. If the key doesn't
+// exist or is the last key in the map the zero values and false are returned.
+func (m *OrderedMap[K, V]) Next(key K) (K, V, bool) {
+	elem, exists := m.data[key]
+	if !exists {
+		return entryOf[K, V](nil)
+	}
+	return entryOf(elem.Next())
+}
+
+// Prev returns the key/value pair that precedes the given key. If the key doesn't
+// exist or is the first key in the map the zero values and false are returned.
+func (m *OrderedMap[K, V]) Prev(key K) (K, V, bool) {
+	elem, exists := m.data[key]
+	if !exists {
+		return entryOf[K, V](nil)
+	}
+	return entryOf(elem.Prev())
+}
+
+// InsertBefore inserts the key/value pair directly before mark. If the key already
+// exists its value is replaced and the entry is moved before mark. If mark doesn't
+// exist false is returned and the map is not modified.
+func (m *OrderedMap[K, V]) InsertBefore(mark K, key K, val V) bool {
+	markElem, exists := m.data[mark]
+	if !exists {
+		return false
+	}
+	if elem, exists := m.data[key]; exists {
+		elem.Value = val
+		m.keys.MoveBefore(elem, markElem)
+		return true
+	}
+	m.data[key] = m.keys.InsertBefore(key, val, markElem)
+	return true
+}
+
+// InsertAfter inserts the key/value pair directly after mark. If the key already
+// exists its value is replaced and the entry is moved after mark. If mark doesn't
+// exist false is returned and the map is not modified.
+func (m *OrderedMap[K, V]) InsertAfter(mark K, key K, val V) bool {
+	markElem, exists := m.data[mark]
+	if !exists {
+		return false
+	}
+	if elem, exists := m.data[key]; exists {
+		elem.Value = val
+		m.keys.MoveAfter(elem, markElem)
+		return true
+	}
+	m.data[key] = m.keys.InsertAfter(key, val, markElem)
+	return true
+}
+
+// IndexOf returns the position of the key in the map, or -1 if the key doesn't
+// exist. IndexOf is an O(n) operation.
+func (m *OrderedMap[K, V]) IndexOf(key K) int {
+	if _, exists := m.data[key]; !exists {
+		return -1
+	}
+	i := 0
+	for e := m.keys.Front(); e != nil; e = e.Next() {
+		if e.Key == key {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// At returns the key/value pair at the given position in the map. If the position
+// is out of range the zero values and false are returned. At is an O(n) operation
+// walking from whichever end of the map is closest to the position.
+func (m *OrderedMap[K, V]) At(i int) (K, V, bool) {
+	if i < 0 || i >= len(m.data) {
+		return entryOf[K, V](nil)
+	}
+	if i < len(m.data)/2 {
+		e := m.keys.Front()
+		for ; i > 0; i-- {
+			e = e.Next()
+		}
+		return entryOf(e)
+	}
+	e := m.keys.Back()
+	for j := len(m.data) - 1; j > i; j-- {
+		e = e.Prev()
+	}
+	return entryOf(e)
+}
+
 // Size returns the number of entries in the OrderedMap
 func (m *OrderedMap[K, V]) Size() int {
 	return len(m.data)
 }
 
-// Keys returns the keys in the order they were inserted.
+// Keys returns the keys in the order of the map, which is the order they were
+// inserted unless the map maintains access order or entries have been moved.
 func (m *OrderedMap[K, V]) Keys() []K {
 	keys := make([]K, 0, len(m.data))
 	for e := m.keys.Front(); e != nil; e = e.Next() {
@@ -91,8 +304,90 @@ func (m *OrderedMap[K, V]) Keys() []K {
 	return keys
 }
 
+// Values returns the values in the order of the map.
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, len(m.data))
+	for e := m.keys.Front(); e != nil; e = e.Next() {
+		values = append(values, e.Value)
+	}
+	return values
+}
+
+// Entries returns the key/value pairs in the order of the map.
+func (m *OrderedMap[K, V]) Entries() []Pair[K, V] {
+	entries := make([]Pair[K, V], 0, len(m.data))
+	for e := m.keys.Front(); e != nil; e = e.Next() {
+		entries = append(entries, Pair[K, V]{Key: e.Key, Value: e.Value})
+	}
+	return entries
+}
+
+// Clear removes all the entries from the OrderedMap.
+func (m *OrderedMap[K, V]) Clear() {
+	m.data = make(map[K]*internal.Element[K, V])
+	m.keys = internal.KeyList[K, V]{}
+}
+
+// Clone returns a new OrderedMap with the same entries in the same order. The
+// clone maintains access order if this map does. Values are copied by assignment
+// so the clone is a shallow copy.
+func (m *OrderedMap[K, V]) Clone() *OrderedMap[K, V] {
+	return m.Filter(func(key K, val V) bool {
+		return true
+	})
+}
+
+// Filter returns a new OrderedMap containing the entries for which the predicate
+// returns true, in the same order.
+func (m *OrderedMap[K, V]) Filter(pred func(key K, val V) bool) *OrderedMap[K, V] {
+	other := NewOrderedMap[K, V]()
+	other.accessOrder = m.accessOrder
+	for e := m.keys.Front(); e != nil; e = e.Next() {
+		if pred(e.Key, e.Value) {
+			other.data[e.Key] = other.keys.PushBack(e.Key, e.Value)
+		}
+	}
+	return other
+}
+
+// Equals returns true if both maps contain the same keys in the same order and
+// the values for each key are equal according to the provided function.
+func (m *OrderedMap[K, V]) Equals(other *OrderedMap[K, V], eq func(a, b V) bool) bool {
+	if len(m.data) != len(other.data) {
+		return false
+	}
+	for e, o := m.keys.Front(), other.keys.Front(); e != nil; e, o = e.Next(), o.Next() {
+		if e.Key != o.Key || !eq(e.Value, o.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualsUnordered returns true if both maps contain the same keys, regardless of
+// their order, and the values for each key are equal according to the provided
+// function.
+func (m *OrderedMap[K, V]) EqualsUnordered(other *OrderedMap[K, V], eq func(a, b V) bool) bool {
+	if len(m.data) != len(other.data) {
+		return false
+	}
+	for key, elem := range m.data {
+		otherElem, exists := other.data[key]
+		if !exists || !eq(elem.Value, otherElem.Value) {
+			return false
+		}
+	}
+	return true
+}
+
 // ForEach iterates through the map entries passing the key/value pair to the
-// provided function/closure in the order they were inserted.
+// provided function/closure in the order of the map, see Keys.
+//
+// The function must not call Get, GetOrDefault or Set on a map which maintains
+// access order. Accessing an entry moves it to the back of the map during the
+// iteration, so accessing the current entry ends the iteration early and
+// accessing an entry which was already visited causes it to be visited again. The
+// value is already passed to the function so there is no need to call Get.
 func (m *OrderedMap[K, V]) ForEach(fn func(key K, val V)) {
 	for e := m.keys.Front(); e != nil; e = e.Next() {
 		fn(e.Key, e.Value)
@@ -100,10 +395,148 @@ func (m *OrderedMap[K, V]) ForEach(fn func(key K, val V)) {
 }
 
 // ForEachReverse iterates through the map entries passing the key/value pairs to
-// the provided function/closure in the reverse order they were inserted (most
-// recently inserted to least recently)
+// the provided function/closure in the reverse order of the map, from the back to
+// the front.
+//
+// Like ForEach, the function must not call Get, GetOrDefault or Set on a map
+// which maintains access order. Moving the current entry to the back of the map
+// causes the entries already visited to be visited again.
 func (m *OrderedMap[K, V]) ForEachReverse(fn func(key K, val V)) {
 	for e := m.keys.Back(); e != nil; e = e.Prev() {
 		fn(e.Key, e.Value)
 	}
 }
+
+// recordAccess moves the element to the back of the map if the map maintains
+// access order.
+func (m *OrderedMap[K, V]) recordAccess(elem *internal.Element[K, V]) {
+	if m.accessOrder {
+		m.keys.MoveToBack(elem)
+	}
+}
+
+// Iter returns a cursor for iterating through the map entries in order starting
+// from the front of the map.
+func (m *OrderedMap[K, V]) Iter() *OrderedMapIterator[K, V] {
+	return &OrderedMapIterator[K, V]{
+		m:    m,
+		next: m.keys.Front(),
+	}
+}
+
+// IterFrom returns a cursor for iterating through the map entries in order
+// starting from the given key. If the key doesn't exist the cursor yields no
+// entries.
+func (m *OrderedMap[K, V]) IterFrom(key K) *OrderedMapIterator[K, V] {
+	return &OrderedMapIterator[K, V]{
+		m:    m,
+		next: m.data[key],
+	}
+}
+
+// IterReverse returns a cursor for iterating through the map entries in reverse
+// order starting from the back of the map.
+func (m *OrderedMap[K, V]) IterReverse() *OrderedMapIterator[K, V] {
+	return &OrderedMapIterator[K, V]{
+		m:       m,
+		next:    m.keys.Back(),
+		reverse: true,
+	}
+}
+
+// MapValues returns a new OrderedMap with the same keys in the same order where
+// each value is the result of applying the provided function to the key/value
+// pair of the source map.
+func MapValues[K comparable, V any, R any](m *OrderedMap[K, V], fn func(key K, val V) R) *OrderedMap[K, R] {
+	other := NewOrderedMap[K, R]()
+	other.accessOrder = m.accessOrder
+	for e := m.keys.Front(); e != nil; e = e.Next() {
+		other.data[e.Key] = other.keys.PushBack(e.Key, fn(e.Key, e.Value))
+	}
+	return other
+}
+
+func (m *OrderedMap[K, V]) pop(elem *internal.Element[K, V]) (K, V, bool) {
+	if elem == nil {
+		return entryOf[K, V](nil)
+	}
+	m.keys.Remove(elem)
+	delete(m.data, elem.Key)
+	return elem.Key, elem.Value, true
+}
+
+// entryOf returns the key/value pair of the element, or the zero values and false
+// if the element is nil.
+func entryOf[K comparable, V any](elem *internal.Element[K, V]) (K, V, bool) {
+	if elem == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return elem.Key, elem.Value, true
+}
+
+// OrderedMapIterator is a stateful cursor for iterating through an OrderedMap.
+// Unlike ForEach the iteration can be stopped at any point, and the current entry
+// can be updated with SetValue or removed with Remove.
+//
+//	for it := m.Iter(); it.Next(); {
+//		if expired(it.Value()) {
+//			it.Remove()
+//		}
+//	}
+//
+// Removing the current entry, either through Remove or Delete on the OrderedMap,
+// is safe and doesn't affect the iteration. Removing or moving any other entry
+// while iterating may cause entries to be skipped.
+type OrderedMapIterator[K comparable, V any] struct {
+	m       *OrderedMap[K, V]
+	current *internal.Element[K, V]
+	next    *internal.Element[K, V]
+	reverse bool
+}
+
+// Next moves the cursor to the next entry and returns a boolean indicating if
+// there is a valid entry.
+func (it *OrderedMapIterator[K, V]) Next() bool {
+	it.current = it.next
+	if it.current == nil {
+		return false
+	}
+	// The following element is captured before the caller has a chance to remove
+	// the current element, which unlinks it from the list.
+	if it.reverse {
+		it.next = it.current.Prev()
+	} else {
+		it.next = it.current.Next()
+	}
+	return true
+}
+
+// Key returns the key of the current entry.
+func (it *OrderedMapIterator[K, V]) Key() K {
+	return it.current.Key
+}
+
+// Value returns the value of the current entry. Reading the value through the
+// cursor doesn't count as an access for a map maintaining access order.
+func (it *OrderedMapIterator[K, V]) Value() V {
+	return it.current.Value
+}
+
+// SetValue replaces the value of the current entry without changing its position.
+func (it *OrderedMapIterator[K, V]) SetValue(val V) {
+	it.current.Value = val
+}
+
+// Remove removes the current entry from the OrderedMap. The cursor remains valid
+// and Next moves to the entry that followed the removed entry. Remove returns
+// false if the current entry was already removed.
+func (it *OrderedMapIterator[K, V]) Remove() bool {
+	if elem, exists := it.m.data[it.current.Key]; !exists || elem != it.current {
+		return false
+	}
+	it.m.keys.Remove(it.current)
+	delete(it.m.data, it.current.Key)
+	return true
+}
@@ -0,0 +1,241 @@
+package collections
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/jkratz55/collections-go/internal"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalJSON marshals an OrderedMap into binary JSON representation as a JSON
+// object with the keys in the order of the map.
+//
+// JSON object keys must be strings so keys are encoded following the same rules
+// as encoding/json uses for maps. Keys of a string kind are used directly, keys
+// implementing encoding.TextMarshaler are marshaled, and keys of an integer kind
+// are formatted as base 10. Any other key type results in an error.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for e := m.keys.Front(); e != nil; e = e.Next() {
+		if e != m.keys.Front() {
+			buf.WriteByte(',')
+		}
+		key, err := marshalKeyText(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		keyData, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyData)
+		buf.WriteByte(':')
+		valData, err := json.Marshal(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(valData)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON unmarshalls binary JSON representation of an OrderedMap into this
+// instance of OrderedMap. The entries are inserted in the order they appear in the
+// JSON object. Keys are decoded following the same rules described by MarshalJSON.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		// JSON null leaves the OrderedMap unmodified like encoding/json does
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("cannot unmarshal %v into OrderedMap, expected JSON object", tok)
+	}
+
+	m.lazyInit()
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := unmarshalKeyText[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var val V
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+		m.Set(key, val)
+	}
+
+	// Consume the closing delimiter of the object
+	_, err = dec.Token()
+	return err
+}
+
+// MarshalMsgpack marshals an OrderedMap into binary msgpack representation as a
+// msgpack map with the keys in the order of the map.
+func (m *OrderedMap[K, V]) MarshalMsgpack() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	if err := enc.EncodeMapLen(len(m.data)); err != nil {
+		return nil, err
+	}
+	for e := m.keys.Front(); e != nil; e = e.Next() {
+		if err := enc.Encode(e.Key); err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(e.Value); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalMsgpack unmarshalls binary msgpack representation of an OrderedMap
+// into this instance of OrderedMap. The entries are inserted in the order they
+// appear in the msgpack map.
+func (m *OrderedMap[K, V]) UnmarshalMsgpack(data []byte) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	n, err := dec.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+
+	m.lazyInit()
+	// A nil msgpack map is decoded as a length of -1, so the loop is skipped
+	for i := 0; i < n; i++ {
+		var key K
+		if err := dec.Decode(&key); err != nil {
+			return err
+		}
+		var val V
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+		m.Set(key, val)
+	}
+	return nil
+}
+
+// MarshalYAML marshals an OrderedMap into a YAML mapping with the keys in the
+// order of the map.
+func (m *OrderedMap[K, V]) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Tag:     "!!map",
+		Content: make([]*yaml.Node, 0, len(m.data)*2),
+	}
+	for e := m.keys.Front(); e != nil; e = e.Next() {
+		var keyNode, valNode yaml.Node
+		if err := keyNode.Encode(e.Key); err != nil {
+			return nil, err
+		}
+		if err := valNode.Encode(e.Value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &keyNode, &valNode)
+	}
+	return node, nil
+}
+
+// UnmarshalYAML unmarshalls a YAML mapping into this instance of OrderedMap. The
+// entries are inserted in the order they appear in the YAML mapping.
+func (m *OrderedMap[K, V]) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+	if value.Tag == "!!null" {
+		return nil
+	}
+	if value.Kind != yaml.MappingNode {
+		return fmt.Errorf("cannot unmarshal YAML %s into OrderedMap, expected mapping", value.Tag)
+	}
+
+	m.lazyInit()
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		var key K
+		if err := value.Content[i].Decode(&key); err != nil {
+			return err
+		}
+		var val V
+		if err := value.Content[i+1].Decode(&val); err != nil {
+			return err
+		}
+		m.Set(key, val)
+	}
+	return nil
+}
+
+// lazyInit initializes the internal state of a zero-value OrderedMap so that it
+// can be used as the target for unmarshalling.
+func (m *OrderedMap[K, V]) lazyInit() {
+	if m.data == nil {
+		m.data = make(map[K]*internal.Element[K, V])
+	}
+}
+
+// marshalKeyText converts a map key into its text representation following the
+// same rules encoding/json uses for map keys.
+func marshalKeyText[K comparable](key K) (string, error) {
+	rv := reflect.ValueOf(&key).Elem()
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported key type %T, keys must be strings, integers or implement encoding.TextMarshaler", key)
+}
+
+// unmarshalKeyText converts the text representation of a map key produced by
+// marshalKeyText back into the key.
+func unmarshalKeyText[K comparable](text string) (K, error) {
+	var key K
+	rv := reflect.ValueOf(&key).Elem()
+	if rv.Kind() == reflect.String {
+		rv.SetString(text)
+		return key, nil
+	}
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(text))
+		return key, err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, rv.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		rv.SetInt(n)
+		return key, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(text, 10, rv.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		rv.SetUint(n)
+		return key, nil
+	}
+	return key, fmt.Errorf("unsupported key type %T, keys must be strings, integers or implement encoding.TextUnmarshaler", key)
+}
@@ -0,0 +1,145 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+type point struct {
+	X, Y int
+}
+
+func (p point) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d,%d", p.X, p.Y)), nil
+}
+
+func (p *point) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d,%d", &p.X, &p.Y)
+	return err
+}
+
+func TestOrderedMap_MarshalJSON(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("zebra", 1)
+	om.Set("apple", 2)
+	om.Set("mango", 3)
+
+	data, err := json.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"zebra":1,"apple":2,"mango":3}`, string(data))
+
+	empty := NewOrderedMap[string, int]()
+	data, err = json.Marshal(empty)
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(data))
+}
+
+func TestOrderedMap_MarshalJSON_NonStringKeys(t *testing.T) {
+	ints := NewOrderedMap[int, string]()
+	ints.Set(10, "ten")
+	ints.Set(-2, "minus two")
+
+	data, err := json.Marshal(ints)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"10":"ten","-2":"minus two"}`, string(data))
+
+	decodedInts := NewOrderedMap[int, string]()
+	assert.NoError(t, json.Unmarshal(data, decodedInts))
+	assert.Equal(t, []int{10, -2}, decodedInts.Keys())
+
+	points := NewOrderedMap[point, string]()
+	points.Set(point{X: 3, Y: 4}, "b")
+	points.Set(point{X: 1, Y: 2}, "a")
+
+	data, err = json.Marshal(points)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"3,4":"b","1,2":"a"}`, string(data))
+
+	decodedPoints := NewOrderedMap[point, string]()
+	assert.NoError(t, json.Unmarshal(data, decodedPoints))
+	assert.Equal(t, []point{{X: 3, Y: 4}, {X: 1, Y: 2}}, decodedPoints.Keys())
+
+	unsupported := NewOrderedMap[float64, string]()
+	unsupported.Set(1.5, "one and a half")
+	_, err = json.Marshal(unsupported)
+	assert.Error(t, err)
+}
+
+func TestOrderedMap_UnmarshalJSON(t *testing.T) {
+	input := `{"zebra": {"legs": 4}, "apple": {"legs": 0}, "bird": {"legs": 2}}`
+
+	type thing struct {
+		Legs int `json:"legs"`
+	}
+
+	om := NewOrderedMap[string, thing]()
+	err := json.Unmarshal([]byte(input), om)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zebra", "apple", "bird"}, om.Keys())
+	assert.Equal(t, thing{Legs: 2}, om.GetOrDefault("bird", thing{}))
+
+	var embedded struct {
+		Things *OrderedMap[string, thing] `json:"things"`
+	}
+	err = json.Unmarshal([]byte(`{"things": `+input+`}`), &embedded)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zebra", "apple", "bird"}, embedded.Things.Keys())
+
+	err = json.Unmarshal([]byte(`[1, 2, 3]`), NewOrderedMap[string, int]())
+	assert.Error(t, err)
+}
+
+func TestOrderedMap_Msgpack(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("zebra", 1)
+	om.Set("apple", 2)
+	om.Set("mango", 3)
+
+	data, err := msgpack.Marshal(om)
+	assert.NoError(t, err)
+
+	decoded := NewOrderedMap[string, int]()
+	err = msgpack.Unmarshal(data, decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zebra", "apple", "mango"}, decoded.Keys())
+	assert.Equal(t, 3, decoded.GetOrDefault("mango", 0))
+
+	// Decoding into a plain map proves a msgpack map is produced
+	var plain map[string]int
+	assert.NoError(t, msgpack.Unmarshal(data, &plain))
+	assert.Equal(t, map[string]int{"zebra": 1, "apple": 2, "mango": 3}, plain)
+}
+
+func TestOrderedMap_YAML(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("zebra", 1)
+	om.Set("apple", 2)
+	om.Set("mango", 3)
+
+	data, err := yaml.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, "zebra: 1\napple: 2\nmango: 3\n", string(data))
+
+	input := strings.Join([]string{
+		"servers:",
+		"  west: 10.0.0.1",
+		"  east: 10.0.0.2",
+		"  central: 10.0.0.3",
+	}, "\n")
+	var config struct {
+		Servers *OrderedMap[string, string] `yaml:"servers"`
+	}
+	err = yaml.Unmarshal([]byte(input), &config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"west", "east", "central"}, config.Servers.Keys())
+	assert.Equal(t, "10.0.0.2", config.Servers.GetOrDefault("east", ""))
+
+	err = yaml.Unmarshal([]byte("- one\n- two\n"), NewOrderedMap[string, string]())
+	assert.Error(t, err)
+}
@@ -0,0 +1,32 @@
+//go:build go1.23
+
+package collections
+
+import (
+	"iter"
+)
+
+// All returns an iterator over the key/value pairs of the map in order, for use
+// with range-over-func. Deleting the current key while ranging is safe.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for it := m.Iter(); it.Next(); {
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the key/value pairs of the map in reverse
+// order, for use with range-over-func. Deleting the current key while ranging is
+// safe.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for it := m.IterReverse(); it.Next(); {
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+//go:build go1.23
+
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMap_All(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("one", 1)
+	om.Set("two", 2)
+	om.Set("three", 3)
+
+	var keys []string
+	var vals []int
+	for key, val := range om.All() {
+		keys = append(keys, key)
+		vals = append(vals, val)
+	}
+	assert.Equal(t, []string{"one", "two", "three"}, keys)
+	assert.Equal(t, []int{1, 2, 3}, vals)
+
+	keys = nil
+	for key := range om.All() {
+		if key == "two" {
+			break
+		}
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"one"}, keys)
+
+	for key, val := range om.All() {
+		if val%2 == 1 {
+			om.Delete(key)
+		}
+	}
+	assert.Equal(t, []string{"two"}, om.Keys())
+}
+
+func TestOrderedMap_Backward(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("one", 1)
+	om.Set("two", 2)
+	om.Set("three", 3)
+
+	var keys []string
+	for key := range om.Backward() {
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"three", "two", "one"}, keys)
+}
@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -163,3 +164,331 @@ func TestOrderedMap_Keys(t *testing.T) {
 
 	assert.Equal(t, []string{"1", "2", "3"}, om.Keys())
 }
+
+func TestNewAccessOrderedMap(t *testing.T) {
+	om := NewAccessOrderedMap[string, int]()
+	om.Set("one", 1)
+	om.Set("two", 2)
+	om.Set("three", 3)
+	assert.Equal(t, []string{"one", "two", "three"}, om.Keys())
+
+	val, ok := om.Get("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, []string{"two", "three", "one"}, om.Keys())
+
+	assert.Equal(t, 2, om.GetOrDefault("two", 0))
+	assert.Equal(t, []string{"three", "one", "two"}, om.Keys())
+
+	assert.False(t, om.Set("three", 33))
+	assert.Equal(t, []string{"one", "two", "three"}, om.Keys())
+
+	assert.True(t, om.Contains("one"))
+	assert.Equal(t, []string{"one", "two", "three"}, om.Keys())
+
+	// Insertion ordered maps are not affected by access
+	insertion := NewOrderedMap[string, int]()
+	insertion.Set("one", 1)
+	insertion.Set("two", 2)
+	insertion.Get("one")
+	insertion.Set("one", 11)
+	assert.Equal(t, []string{"one", "two"}, insertion.Keys())
+}
+
+func TestOrderedMap_Move(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("a", 1)
+	om.Set("b", 2)
+	om.Set("c", 3)
+	om.Set("d", 4)
+
+	assert.True(t, om.MoveToFront("c"))
+	assert.Equal(t, []string{"c", "a", "b", "d"}, om.Keys())
+
+	assert.True(t, om.MoveToBack("c"))
+	assert.Equal(t, []string{"a", "b", "d", "c"}, om.Keys())
+
+	assert.True(t, om.MoveBefore("c", "a"))
+	assert.Equal(t, []string{"c", "a", "b", "d"}, om.Keys())
+
+	assert.True(t, om.MoveAfter("c", "d"))
+	assert.Equal(t, []string{"a", "b", "d", "c"}, om.Keys())
+
+	assert.True(t, om.MoveAfter("a", "b"))
+	assert.Equal(t, []string{"b", "a", "d", "c"}, om.Keys())
+
+	assert.True(t, om.MoveBefore("c", "d"))
+	assert.Equal(t, []string{"b", "a", "c", "d"}, om.Keys())
+
+	assert.True(t, om.MoveBefore("a", "a"))
+	assert.Equal(t, []string{"b", "a", "c", "d"}, om.Keys())
+
+	assert.False(t, om.MoveToFront("z"))
+	assert.False(t, om.MoveBefore("a", "z"))
+	assert.False(t, om.MoveAfter("z", "a"))
+
+	var reversed []string
+	om.ForEachReverse(func(key string, val int) {
+		reversed = append(reversed, key)
+	})
+	assert.Equal(t, []string{"d", "c", "a", "b"}, reversed)
+}
+
+func TestOrderedMap_FirstLast(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+
+	_, _, ok := om.First()
+	assert.False(t, ok)
+	_, _, ok = om.PopLast()
+	assert.False(t, ok)
+
+	om.Set("one", 1)
+	om.Set("two", 2)
+	om.Set("three", 3)
+
+	key, val, ok := om.First()
+	assert.True(t, ok)
+	assert.Equal(t, "one", key)
+	assert.Equal(t, 1, val)
+
+	key, val, ok = om.Last()
+	assert.True(t, ok)
+	assert.Equal(t, "three", key)
+	assert.Equal(t, 3, val)
+
+	key, val, ok = om.PopFirst()
+	assert.True(t, ok)
+	assert.Equal(t, "one", key)
+	assert.Equal(t, 1, val)
+
+	key, val, ok = om.PopLast()
+	assert.True(t, ok)
+	assert.Equal(t, "three", key)
+	assert.Equal(t, 3, val)
+
+	assert.Equal(t, []string{"two"}, om.Keys())
+	assert.False(t, om.Contains("one"))
+	assert.False(t, om.Contains("three"))
+}
+
+func TestOrderedMap_NextPrev(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("one", 1)
+	om.Set("two", 2)
+	om.Set("three", 3)
+
+	key, val, ok := om.Next("one")
+	assert.True(t, ok)
+	assert.Equal(t, "two", key)
+	assert.Equal(t, 2, val)
+
+	_, _, ok = om.Next("three")
+	assert.False(t, ok)
+
+	key, val, ok = om.Prev("three")
+	assert.True(t, ok)
+	assert.Equal(t, "two", key)
+	assert.Equal(t, 2, val)
+
+	_, _, ok = om.Prev("one")
+	assert.False(t, ok)
+	_, _, ok = om.Next("missing")
+	assert.False(t, ok)
+}
+
+func TestOrderedMap_InsertBeforeAfter(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("a", 1)
+	om.Set("c", 3)
+
+	assert.True(t, om.InsertBefore("c", "b", 2))
+	assert.Equal(t, []string{"a", "b", "c"}, om.Keys())
+
+	assert.True(t, om.InsertAfter("c", "d", 4))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, om.Keys())
+
+	assert.True(t, om.InsertBefore("a", "start", 0))
+	assert.Equal(t, []string{"start", "a", "b", "c", "d"}, om.Keys())
+
+	// Existing keys are moved and their value replaced
+	assert.True(t, om.InsertAfter("d", "a", 11))
+	assert.Equal(t, []string{"start", "b", "c", "d", "a"}, om.Keys())
+	assert.Equal(t, 11, om.GetOrDefault("a", 0))
+
+	assert.False(t, om.InsertBefore("missing", "x", 0))
+	assert.False(t, om.InsertAfter("missing", "x", 0))
+	assert.False(t, om.Contains("x"))
+
+	key, _, ok := om.Last()
+	assert.True(t, ok)
+	assert.Equal(t, "a", key)
+}
+
+func TestOrderedMap_IndexOfAt(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	keys := []string{"a", "b", "c", "d", "e"}
+	for i, key := range keys {
+		om.Set(key, i)
+	}
+
+	for i, key := range keys {
+		assert.Equal(t, i, om.IndexOf(key))
+
+		k, v, ok := om.At(i)
+		assert.True(t, ok)
+		assert.Equal(t, key, k)
+		assert.Equal(t, i, v)
+	}
+
+	assert.Equal(t, -1, om.IndexOf("z"))
+	_, _, ok := om.At(-1)
+	assert.False(t, ok)
+	_, _, ok = om.At(5)
+	assert.False(t, ok)
+}
+
+func TestOrderedMap_Iter(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("one", 1)
+	om.Set("two", 2)
+	om.Set("three", 3)
+	om.Set("four", 4)
+
+	var keys []string
+	for it := om.Iter(); it.Next(); {
+		keys = append(keys, it.Key())
+		if it.Key() == "three" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"one", "two", "three"}, keys)
+
+	keys = nil
+	for it := om.IterReverse(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, []string{"four", "three", "two", "one"}, keys)
+
+	keys = nil
+	for it := om.IterFrom("two"); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, []string{"two", "three", "four"}, keys)
+
+	assert.False(t, om.IterFrom("missing").Next())
+}
+
+func TestOrderedMapIterator_Remove(t *testing.T) {
+	om := NewOrderedMap[int, int]()
+	for i := 0; i < 10; i++ {
+		om.Set(i, i)
+	}
+
+	for it := om.Iter(); it.Next(); {
+		if it.Value()%2 == 0 {
+			assert.True(t, it.Remove())
+			assert.False(t, it.Remove())
+		} else {
+			it.SetValue(it.Value() * 10)
+		}
+	}
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, om.Keys())
+	assert.Equal(t, 30, om.GetOrDefault(3, 0))
+
+	// Deleting the current entry through the map is also safe
+	for it := om.IterReverse(); it.Next(); {
+		om.Delete(it.Key())
+	}
+	assert.Equal(t, 0, om.Size())
+}
+
+func TestFromPairs(t *testing.T) {
+	om := FromPairs(
+		Pair[string, int]{Key: "one", Value: 1},
+		Pair[string, int]{Key: "two", Value: 2},
+		Pair[string, int]{Key: "one", Value: 11},
+	)
+	assert.Equal(t, []string{"one", "two"}, om.Keys())
+	assert.Equal(t, []int{11, 2}, om.Values())
+	assert.Equal(t, []Pair[string, int]{
+		{Key: "one", Value: 11},
+		{Key: "two", Value: 2},
+	}, om.Entries())
+}
+
+func TestOrderedMap_Clone(t *testing.T) {
+	om := NewAccessOrderedMap[string, int]()
+	om.Set("one", 1)
+	om.Set("two", 2)
+
+	clone := om.Clone()
+	assert.Equal(t, om.Entries(), clone.Entries())
+
+	clone.Set("three", 3)
+	assert.False(t, om.Contains("three"))
+
+	// The clone keeps maintaining access order
+	clone.Get("one")
+	assert.Equal(t, []string{"two", "three", "one"}, clone.Keys())
+	assert.Equal(t, []string{"one", "two"}, om.Keys())
+}
+
+func TestOrderedMap_Clear(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("one", 1)
+	om.Set("two", 2)
+
+	om.Clear()
+	assert.Equal(t, 0, om.Size())
+	assert.Empty(t, om.Keys())
+
+	om.Set("three", 3)
+	assert.Equal(t, []string{"three"}, om.Keys())
+}
+
+func TestOrderedMap_Filter(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	for i, key := range []string{"a", "b", "c", "d"} {
+		om.Set(key, i)
+	}
+
+	even := om.Filter(func(key string, val int) bool {
+		return val%2 == 0
+	})
+	assert.Equal(t, []string{"a", "c"}, even.Keys())
+	assert.Equal(t, 4, om.Size())
+}
+
+func TestMapValues(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("b", 2)
+	om.Set("a", 1)
+
+	mapped := MapValues(om, func(key string, val int) string {
+		return strings.Repeat(key, val)
+	})
+	assert.Equal(t, []Pair[string, string]{
+		{Key: "b", Value: "bb"},
+		{Key: "a", Value: "a"},
+	}, mapped.Entries())
+}
+
+func TestOrderedMap_Equals(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	om1 := FromPairs(Pair[string, int]{"a", 1}, Pair[string, int]{"b", 2})
+	om2 := FromPairs(Pair[string, int]{"a", 1}, Pair[string, int]{"b", 2})
+	reordered := FromPairs(Pair[string, int]{"b", 2}, Pair[string, int]{"a", 1})
+	different := FromPairs(Pair[string, int]{"a", 1}, Pair[string, int]{"b", 3})
+	smaller := FromPairs(Pair[string, int]{"a", 1})
+
+	assert.True(t, om1.Equals(om2, eq))
+	assert.False(t, om1.Equals(reordered, eq))
+	assert.False(t, om1.Equals(different, eq))
+	assert.False(t, om1.Equals(smaller, eq))
+
+	assert.True(t, om1.EqualsUnordered(om2, eq))
+	assert.True(t, om1.EqualsUnordered(reordered, eq))
+	assert.False(t, om1.EqualsUnordered(different, eq))
+	assert.False(t, om1.EqualsUnordered(smaller, eq))
+}
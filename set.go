@@ -2,7 +2,6 @@ package collections
 
 import (
 	"encoding/json"
-	"reflect"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -17,11 +16,21 @@ import (
 // The zero-value of Set is not usable. Set should be created and initialized using
 // NewSet function.
 //
-// Set supports marshaling/unmarshalling for json and msgpack out of the box.
+// Set supports marshaling/unmarshalling for json, msgpack, yaml and text out of
+// the box. By default the elements are marshaled in the random order of the
+// underlying map, see SetMarshalOrder and RegisterMarshalOrder for deterministic
+// output.
 //
 // Note: Set is not thread safe.
 type Set[T comparable] struct {
-	data map[T]struct{}
+	data         map[T]struct{}
+	marshalOrder func(a, b T) int
+}
+
+// Iterable is implemented by collections which can be iterated with ForEach, such
+// as Set and SortedSet.
+type Iterable[T any] interface {
+	ForEach(fn func(val T))
 }
 
 // NewSet creates and initializes a Set
@@ -39,6 +48,13 @@ func (s *Set[T]) Add(vals ...T) {
 	}
 }
 
+// AddAll adds all the elements of the provided Iterable into the Set.
+func (s *Set[T]) AddAll(vals Iterable[T]) {
+	vals.ForEach(func(val T) {
+		s.data[val] = struct{}{}
+	})
+}
+
 // Remove removes/deletes an element from the Set. If the element doesn't exist
 // this is a no-op.
 func (s *Set[T]) Remove(val T) {
@@ -57,9 +73,81 @@ func (s *Set[T]) Size() int {
 	return len(s.data)
 }
 
+// Pop removes and returns an arbitrary element from the Set. If the Set is empty
+// the zero value and false are returned.
+func (s *Set[T]) Pop() (T, bool) {
+	for key := range s.data {
+		delete(s.data, key)
+		return key, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Clear removes all the elements from the Set.
+func (s *Set[T]) Clear() {
+	s.data = make(map[T]struct{})
+}
+
 // Equals returns a boolean indicating if the Set is equal to the provided Set.
 func (s *Set[T]) Equals(other *Set[T]) bool {
-	return reflect.DeepEqual(s.data, other.data)
+	if len(s.data) != len(other.data) {
+		return false
+	}
+	for key := range s.data {
+		if _, found := other.data[key]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns a new Set containing the elements for which the predicate returns
+// true.
+func (s *Set[T]) Filter(pred func(val T) bool) *Set[T] {
+	newSet := NewSet[T]()
+	for key := range s.data {
+		if pred(key) {
+			newSet.data[key] = struct{}{}
+		}
+	}
+	return newSet
+}
+
+// Any returns true if the predicate returns true for at least one element of the
+// Set. Any returns false for an empty Set.
+func (s *Set[T]) Any(pred func(val T) bool) bool {
+	for key := range s.data {
+		if pred(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true if the predicate returns true for every element of the Set.
+// All returns true for an empty Set.
+func (s *Set[T]) All(pred func(val T) bool) bool {
+	for key := range s.data {
+		if !pred(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Partition returns two new Sets, the first containing the elements for which the
+// predicate returns true and the second containing the rest.
+func (s *Set[T]) Partition(pred func(val T) bool) (*Set[T], *Set[T]) {
+	matched, rest := NewSet[T](), NewSet[T]()
+	for key := range s.data {
+		if pred(key) {
+			matched.data[key] = struct{}{}
+		} else {
+			rest.data[key] = struct{}{}
+		}
+	}
+	return matched, rest
 }
 
 // ForEach iterates through the Set passing the value to the provided function.
@@ -93,6 +181,7 @@ func (s *Set[T]) AsSlice() []T {
 // Clone does a deep copy and returns a new Set with the same elements/deque.
 func (s *Set[T]) Clone() *Set[T] {
 	other := NewSet[T]()
+	other.marshalOrder = s.marshalOrder
 	for key, _ := range s.data {
 		other.Add(key)
 	}
@@ -110,9 +199,47 @@ func (s *Set[T]) Union(other *Set[T]) *Set[T] {
 
 // Difference returns a new Set containing all the elements in other that did not
 // exists in current Set.
+//
+// Deprecated: Difference returns other \ s which is the reverse of the
+// mathematical set difference s \ other its name suggests. Use other.Subtract(s)
+// for the same result, or s.Subtract(other) for s \ other.
 func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	return other.Subtract(s)
+}
+
+// Subtract returns a new Set containing the elements in this Set that are not in
+// other, the set difference s \ other.
+func (s *Set[T]) Subtract(other *Set[T]) *Set[T] {
 	newSet := NewSet[T]()
-	for key, _ := range other.data {
+	for key := range s.data {
+		if _, found := other.data[key]; !found {
+			newSet.Add(key)
+		}
+	}
+	return newSet
+}
+
+// Intersection returns a new Set containing the elements in both this Set and
+// other. The smaller of the two sets is iterated.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	smaller, larger := s, other
+	if len(smaller.data) > len(larger.data) {
+		smaller, larger = larger, smaller
+	}
+	newSet := NewSet[T]()
+	for key := range smaller.data {
+		if _, found := larger.data[key]; found {
+			newSet.Add(key)
+		}
+	}
+	return newSet
+}
+
+// SymmetricDifference returns a new Set containing the elements which are in
+// either this Set or other but not in both.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	newSet := s.Subtract(other)
+	for key := range other.data {
 		if _, found := s.data[key]; !found {
 			newSet.Add(key)
 		}
@@ -120,9 +247,86 @@ func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
 	return newSet
 }
 
+// IsSubsetOf returns true if every element of this Set is in other.
+func (s *Set[T]) IsSubsetOf(other *Set[T]) bool {
+	if len(s.data) > len(other.data) {
+		return false
+	}
+	for key := range s.data {
+		if _, found := other.data[key]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf returns true if every element of other is in this Set.
+func (s *Set[T]) IsSupersetOf(other *Set[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// IsDisjoint returns true if this Set and other have no elements in common. The
+// smaller of the two sets is iterated.
+func (s *Set[T]) IsDisjoint(other *Set[T]) bool {
+	smaller, larger := s, other
+	if len(smaller.data) > len(larger.data) {
+		smaller, larger = larger, smaller
+	}
+	for key := range smaller.data {
+		if _, found := larger.data[key]; found {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionInPlace adds all the elements of other into this Set.
+func (s *Set[T]) UnionInPlace(other *Set[T]) {
+	for key := range other.data {
+		s.data[key] = struct{}{}
+	}
+}
+
+// RetainAll removes the elements from this Set that are not in other, leaving the
+// intersection of both sets.
+func (s *Set[T]) RetainAll(other *Set[T]) {
+	for key := range s.data {
+		if _, found := other.data[key]; !found {
+			delete(s.data, key)
+		}
+	}
+}
+
+// RemoveAll removes the elements from this Set that are in other, leaving the set
+// difference s \ other.
+func (s *Set[T]) RemoveAll(other *Set[T]) {
+	if len(other.data) < len(s.data) {
+		for key := range other.data {
+			delete(s.data, key)
+		}
+		return
+	}
+	for key := range s.data {
+		if _, found := other.data[key]; found {
+			delete(s.data, key)
+		}
+	}
+}
+
+// Map returns a new Set containing the result of applying the provided function to
+// each element of the Set. Elements mapping to the same value are merged, so the
+// returned Set may be smaller than the source Set.
+func Map[T comparable, R comparable](s *Set[T], fn func(val T) R) *Set[R] {
+	newSet := NewSet[R]()
+	for key := range s.data {
+		newSet.data[fn(key)] = struct{}{}
+	}
+	return newSet
+}
+
 // MarshalJSON marshals a Set into binary JSON representation
 func (s *Set[T]) MarshalJSON() ([]byte, error) {
-	elements := s.AsSlice()
+	elements := s.marshalSlice()
 	return json.Marshal(elements)
 }
 
@@ -133,16 +337,14 @@ func (s *Set[T]) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
+	s.lazyInit()
 	s.Add(raw...)
 	return nil
 }
 
 // MarshalMsgpack marshals a Set into binary msgpack representation.
 func (s *Set[T]) MarshalMsgpack() ([]byte, error) {
-	elements := s.AsSlice()
+	elements := s.marshalSlice()
 	return msgpack.Marshal(elements)
 }
 
@@ -153,9 +355,7 @@ func (s *Set[T]) UnmarshalMsgpack(data []byte) error {
 	if err := msgpack.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	if s.data == nil {
-		s.data = make(map[T]struct{})
-	}
+	s.lazyInit()
 	s.Add(raw...)
 	return nil
 }
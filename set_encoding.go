@@ -0,0 +1,195 @@
+package collections
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// marshalOrders holds the comparators registered with RegisterMarshalOrder keyed
+// by the element type.
+var marshalOrders sync.Map
+
+// RegisterMarshalOrder registers a comparator used to sort the elements of every
+// Set[T] when it is marshaled, making the output deterministic. This is useful
+// when sets are embedded in structs that are hashed or diffed, where configuring
+// each Set with SetMarshalOrder isn't practical. A comparator configured on a Set
+// with SetMarshalOrder takes precedence. Registering a nil comparator removes the
+// registration for T.
+//
+// For element types satisfying Ordered the natural order can be used:
+//
+//	collections.RegisterMarshalOrder(collections.Compare[string])
+//
+// As with SetMarshalOrder, distinct elements the comparator ties are marshaled in
+// an unspecified order.
+//
+// RegisterMarshalOrder is safe to call concurrently, but is intended to be called
+// during initialization.
+func RegisterMarshalOrder[T comparable](cmp func(a, b T) int) {
+	key := reflect.TypeOf((*T)(nil)).Elem()
+	if cmp == nil {
+		marshalOrders.Delete(key)
+		return
+	}
+	marshalOrders.Store(key, cmp)
+}
+
+// SetMarshalOrder configures a comparator used to sort the elements of this Set
+// when it is marshaled, making the output deterministic. A nil comparator restores
+// the default behavior. The comparator is retained by Clone.
+//
+// The comparator should be a strict total order, only returning zero for equal
+// elements. The sort is stable, but the elements are read from a map, so distinct
+// elements the comparator ties may still be marshaled in a different order each
+// time.
+func (s *Set[T]) SetMarshalOrder(cmp func(a, b T) int) {
+	s.marshalOrder = cmp
+}
+
+// MarshalYAML marshals a Set into a YAML sequence.
+func (s *Set[T]) MarshalYAML() (interface{}, error) {
+	return s.marshalSlice(), nil
+}
+
+// UnmarshalYAML unmarshalls a YAML sequence into this instance of Set.
+func (s *Set[T]) UnmarshalYAML(value *yaml.Node) error {
+	var raw []T
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	s.lazyInit()
+	s.Add(raw...)
+	return nil
+}
+
+// MarshalText marshals a Set into a single line of comma separated values. Each
+// element is converted to text following the same rules encoding/json uses for
+// map keys, so the element type must be a string, an integer or implement
+// encoding.TextMarshaler. Elements containing commas, quotes or line breaks are
+// quoted as in CSV, with quotes doubled.
+func (s *Set[T]) MarshalText() ([]byte, error) {
+	elements := s.marshalSlice()
+	var buf bytes.Buffer
+	for i, val := range elements {
+		text, err := marshalKeyText(val)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		// A lone empty element is quoted otherwise it would be read back as an
+		// empty Set.
+		if strings.ContainsAny(text, ",\"\r\n") || (text == "" && len(elements) == 1) {
+			buf.WriteByte('"')
+			buf.WriteString(strings.ReplaceAll(text, `"`, `""`))
+			buf.WriteByte('"')
+		} else {
+			buf.WriteString(text)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText unmarshalls the comma separated text representation produced by
+// MarshalText into this instance of Set.
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	s.lazyInit()
+	fields, err := splitTextRecord(string(text))
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		val, err := unmarshalKeyText[T](field)
+		if err != nil {
+			return err
+		}
+		s.Add(val)
+	}
+	return nil
+}
+
+// marshalSlice returns the elements of the Set sorted by the configured
+// comparator, or in random order if no comparator is configured.
+func (s *Set[T]) marshalSlice() []T {
+	elements := s.AsSlice()
+	cmp := s.marshalOrder
+	if cmp == nil {
+		if registered, ok := marshalOrders.Load(reflect.TypeOf((*T)(nil)).Elem()); ok {
+			cmp = registered.(func(a, b T) int)
+		}
+	}
+	if cmp != nil {
+		sort.SliceStable(elements, func(i, j int) bool {
+			return cmp(elements[i], elements[j]) < 0
+		})
+	}
+	return elements
+}
+
+// lazyInit initializes the internal state of a zero-value Set so that it can be
+// used as the target for unmarshalling.
+func (s *Set[T]) lazyInit() {
+	if s.data == nil {
+		s.data = make(map[T]struct{})
+	}
+}
+
+// splitTextRecord splits a single line of comma separated values written by
+// MarshalText into its fields. Unlike encoding/csv line breaks within quoted fields
+// are preserved exactly, a carriage return isn't dropped. A single trailing line
+// break is ignored.
+func splitTextRecord(text string) ([]string, error) {
+	if strings.TrimRight(text, "\r\n") == "" && !strings.Contains(text, `"`) {
+		return nil, nil
+	}
+	var fields []string
+	for pos := 0; ; {
+		var field strings.Builder
+		if pos < len(text) && text[pos] == '"' {
+			pos++
+			for {
+				end := strings.IndexByte(text[pos:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("cannot unmarshal text into Set: unterminated quoted field")
+				}
+				field.WriteString(text[pos : pos+end])
+				pos += end + 1
+				if pos < len(text) && text[pos] == '"' {
+					field.WriteByte('"')
+					pos++
+					continue
+				}
+				break
+			}
+		} else {
+			end := strings.IndexAny(text[pos:], ",\"\r\n")
+			if end < 0 {
+				end = len(text) - pos
+			}
+			field.WriteString(text[pos : pos+end])
+			pos += end
+		}
+		fields = append(fields, field.String())
+
+		rest := text[pos:]
+		switch {
+		case rest == "" || rest == "\n" || rest == "\r\n":
+			return fields, nil
+		case rest[0] == ',':
+			pos++
+		case rest[0] == '"':
+			return nil, fmt.Errorf("cannot unmarshal text into Set: unexpected quote in field")
+		case rest[0] == '\r' || rest[0] == '\n':
+			return nil, fmt.Errorf("cannot unmarshal multiple lines of text into Set")
+		default:
+			return nil, fmt.Errorf("cannot unmarshal text into Set: unexpected text after quoted field")
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSet_SetMarshalOrder(t *testing.T) {
+	set := NewSet[string]()
+	set.Add("pizza", "tacos", "hamburger", "apple")
+	set.SetMarshalOrder(Compare[string])
+
+	data, err := json.Marshal(set)
+	assert.NoError(t, err)
+	assert.Equal(t, `["apple","hamburger","pizza","tacos"]`, string(data))
+
+	data, err = msgpack.Marshal(set)
+	assert.NoError(t, err)
+	expected, _ := msgpack.Marshal([]string{"apple", "hamburger", "pizza", "tacos"})
+	assert.Equal(t, expected, data)
+
+	// The order is retained by Clone
+	data, err = json.Marshal(set.Clone())
+	assert.NoError(t, err)
+	assert.Equal(t, `["apple","hamburger","pizza","tacos"]`, string(data))
+
+	// Custom comparators are supported, such as descending order
+	set.SetMarshalOrder(func(a, b string) int {
+		return Compare(b, a)
+	})
+	data, err = json.Marshal(set)
+	assert.NoError(t, err)
+	assert.Equal(t, `["tacos","pizza","hamburger","apple"]`, string(data))
+}
+
+func TestRegisterMarshalOrder(t *testing.T) {
+	type tagged struct {
+		Tags *Set[int] `json:"tags"`
+	}
+
+	RegisterMarshalOrder(Compare[int])
+	defer RegisterMarshalOrder[int](nil)
+
+	var decoded tagged
+	assert.NoError(t, json.Unmarshal([]byte(`{"tags":[5,3,9,1]}`), &decoded))
+	data, err := json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":[1,3,5,9]}`, string(data))
+
+	// A per set comparator takes precedence
+	decoded.Tags.SetMarshalOrder(func(a, b int) int {
+		return Compare(b, a)
+	})
+	data, err = json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":[9,5,3,1]}`, string(data))
+}
+
+func TestSet_YAML(t *testing.T) {
+	set := NewSet[string]()
+	set.Add("pizza", "tacos", "apple")
+	set.SetMarshalOrder(Compare[string])
+
+	data, err := yaml.Marshal(set)
+	assert.NoError(t, err)
+	assert.Equal(t, "- apple\n- pizza\n- tacos\n", string(data))
+
+	var decoded Set[string]
+	assert.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.True(t, set.Equals(&decoded))
+
+	err = yaml.Unmarshal([]byte("key: value"), &decoded)
+	assert.Error(t, err)
+}
+
+func TestSet_Text(t *testing.T) {
+	set := NewSet[string]()
+	set.Add("pizza", "mac, cheese", `say "hi"`)
+	set.SetMarshalOrder(Compare[string])
+
+	data, err := set.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, `"mac, cheese",pizza,"say ""hi"""`, string(data))
+
+	var decoded Set[string]
+	assert.NoError(t, decoded.UnmarshalText(data))
+	assert.True(t, set.Equals(&decoded))
+
+	ints := NewSet[int]()
+	assert.NoError(t, ints.UnmarshalText([]byte("3,1,2")))
+	ints.SetMarshalOrder(Compare[int])
+	data, err = ints.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2,3", string(data))
+
+	data, err = NewSet[int]().MarshalText()
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+	empty := NewSet[int]()
+	assert.NoError(t, empty.UnmarshalText(data))
+	assert.Equal(t, 0, empty.Size())
+
+	assert.Error(t, ints.UnmarshalText([]byte("1,two")))
+	assert.Error(t, ints.UnmarshalText([]byte("1,2\n3")))
+
+	blank := NewSet[string]()
+	blank.Add("")
+	data, err = blank.MarshalText()
+	assert.NoError(t, err)
+	decodedBlank := NewSet[string]()
+	assert.NoError(t, decodedBlank.UnmarshalText(data))
+	assert.True(t, blank.Equals(decodedBlank))
+
+	floats := NewSet[float64]()
+	floats.Add(1.5)
+	_, err = floats.MarshalText()
+	assert.Error(t, err)
+
+	assert.Error(t, decoded.UnmarshalText([]byte(`"unterminated`)))
+	assert.Error(t, decoded.UnmarshalText([]byte(`"quoted"trailing`)))
+	assert.Error(t, decoded.UnmarshalText([]byte(`bare"quote`)))
+}
+
+func TestSet_Text_SpecialCharacters(t *testing.T) {
+	set := NewSet[string]()
+	set.Add("a,b", `"`, `""`, "crlf\r\nline", "lf\nline", "cr\r", "", " padded ", "plain")
+	set.SetMarshalOrder(Compare[string])
+
+	data, err := set.MarshalText()
+	assert.NoError(t, err)
+
+	decoded := NewSet[string]()
+	assert.NoError(t, decoded.UnmarshalText(data))
+	assert.True(t, set.Equals(decoded))
+
+	// A trailing line break, such as from a file, is ignored
+	decoded = NewSet[string]()
+	assert.NoError(t, decoded.UnmarshalText(append(data, '\r', '\n')))
+	assert.True(t, set.Equals(decoded))
+
+	fields := NewSet[string]()
+	assert.NoError(t, fields.UnmarshalText([]byte("a,,b")))
+	assert.True(t, fields.Equals(setOf("a", "", "b")))
+}
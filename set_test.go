@@ -95,6 +95,76 @@ func TestSet_Equals(t *testing.T) {
 	s4 := NewSet[string]()
 	s4.Add("pizza", "pineapples")
 	assert.False(t, s1.Equals(s4))
+
+	s5 := NewSet[string]()
+	s5.Add("pizza", "tacos", "pineapples")
+	assert.False(t, s1.Equals(s5))
+	assert.True(t, NewSet[string]().Equals(NewSet[string]()))
+}
+
+func TestSet_AddAll(t *testing.T) {
+	s1 := NewSet[int]()
+	s1.Add(1, 2)
+
+	s2 := NewSet[int]()
+	s2.Add(2, 3)
+	s1.AddAll(s2)
+
+	sorted := NewSortedSet[int]()
+	sorted.Add(4, 5)
+	s1.AddAll(sorted)
+
+	assert.Equal(t, map[int]struct{}{1: {}, 2: {}, 3: {}, 4: {}, 5: {}}, s1.data)
+}
+
+func TestSet_PopClear(t *testing.T) {
+	set := NewSet[string]()
+	_, ok := set.Pop()
+	assert.False(t, ok)
+
+	set.Add("pizza", "tacos")
+	val, ok := set.Pop()
+	assert.True(t, ok)
+	assert.Contains(t, []string{"pizza", "tacos"}, val)
+	assert.False(t, set.Contains(val))
+	assert.Equal(t, 1, set.Size())
+
+	set.Add("hamburger")
+	set.Clear()
+	assert.Equal(t, 0, set.Size())
+	set.Add("pasta")
+	assert.True(t, set.Contains("pasta"))
+}
+
+func TestSet_Predicates(t *testing.T) {
+	set := NewSet[int]()
+	set.Add(1, 2, 3, 4, 5)
+	isEven := func(val int) bool { return val%2 == 0 }
+
+	assert.Equal(t, map[int]struct{}{2: {}, 4: {}}, set.Filter(isEven).data)
+	assert.True(t, set.Any(isEven))
+	assert.False(t, set.All(isEven))
+	assert.True(t, set.All(func(val int) bool { return val > 0 }))
+	assert.False(t, NewSet[int]().Any(isEven))
+	assert.True(t, NewSet[int]().All(isEven))
+
+	even, odd := set.Partition(isEven)
+	assert.Equal(t, map[int]struct{}{2: {}, 4: {}}, even.data)
+	assert.Equal(t, map[int]struct{}{1: {}, 3: {}, 5: {}}, odd.data)
+	assert.Equal(t, 5, set.Size())
+}
+
+func TestMap(t *testing.T) {
+	set := NewSet[int]()
+	set.Add(1, 2, 3, 4)
+
+	parity := Map(set, func(val int) string {
+		if val%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	assert.Equal(t, map[string]struct{}{"even": {}, "odd": {}}, parity.data)
 }
 
 func TestSet_Clone(t *testing.T) {
@@ -147,6 +217,103 @@ func TestSet_Difference(t *testing.T) {
 	assert.True(t, s4.Size() == 0)
 }
 
+func TestSet_Subtract(t *testing.T) {
+	s1 := NewSet[string]()
+	s1.Add("pizza", "tacos", "hamburger")
+
+	s2 := NewSet[string]()
+	s2.Add("pizza", "pasta")
+
+	assert.Equal(t, map[string]struct{}{
+		"tacos":     {},
+		"hamburger": {},
+	}, s1.Subtract(s2).data)
+	assert.Equal(t, map[string]struct{}{
+		"pasta": {},
+	}, s2.Subtract(s1).data)
+}
+
+func TestSet_Intersection(t *testing.T) {
+	s1 := NewSet[string]()
+	s1.Add("pizza", "tacos", "hamburger")
+
+	s2 := NewSet[string]()
+	s2.Add("pizza", "tacos", "pasta", "ice cream")
+
+	expected := map[string]struct{}{
+		"pizza": {},
+		"tacos": {},
+	}
+	assert.Equal(t, expected, s1.Intersection(s2).data)
+	assert.Equal(t, expected, s2.Intersection(s1).data)
+	assert.Equal(t, 0, s1.Intersection(NewSet[string]()).Size())
+}
+
+func TestSet_SymmetricDifference(t *testing.T) {
+	s1 := NewSet[int]()
+	s1.Add(1, 2, 3)
+
+	s2 := NewSet[int]()
+	s2.Add(3, 4)
+
+	expected := map[int]struct{}{1: {}, 2: {}, 4: {}}
+	assert.Equal(t, expected, s1.SymmetricDifference(s2).data)
+	assert.Equal(t, expected, s2.SymmetricDifference(s1).data)
+}
+
+func TestSet_Relations(t *testing.T) {
+	s1 := NewSet[int]()
+	s1.Add(1, 2)
+
+	s2 := NewSet[int]()
+	s2.Add(1, 2, 3)
+
+	s3 := NewSet[int]()
+	s3.Add(4, 5)
+
+	empty := NewSet[int]()
+
+	assert.True(t, s1.IsSubsetOf(s2))
+	assert.False(t, s2.IsSubsetOf(s1))
+	assert.True(t, s1.IsSubsetOf(s1))
+	assert.True(t, empty.IsSubsetOf(s1))
+
+	assert.True(t, s2.IsSupersetOf(s1))
+	assert.False(t, s1.IsSupersetOf(s2))
+	assert.True(t, s1.IsSupersetOf(empty))
+
+	assert.True(t, s1.IsDisjoint(s3))
+	assert.True(t, s3.IsDisjoint(s2))
+	assert.False(t, s1.IsDisjoint(s2))
+	assert.True(t, empty.IsDisjoint(empty))
+}
+
+func TestSet_InPlace(t *testing.T) {
+	s1 := NewSet[int]()
+	s1.Add(1, 2, 3)
+
+	other := NewSet[int]()
+	other.Add(3, 4)
+
+	s1.UnionInPlace(other)
+	assert.Equal(t, map[int]struct{}{1: {}, 2: {}, 3: {}, 4: {}}, s1.data)
+
+	s1.RetainAll(other)
+	assert.Equal(t, map[int]struct{}{3: {}, 4: {}}, s1.data)
+
+	s1.Add(1, 2, 5)
+	s1.RemoveAll(other)
+	assert.Equal(t, map[int]struct{}{1: {}, 2: {}, 5: {}}, s1.data)
+
+	large := NewSet[int]()
+	large.Add(1, 6, 7, 8, 9, 10)
+	s1.RemoveAll(large)
+	assert.Equal(t, map[int]struct{}{2: {}, 5: {}}, s1.data)
+
+	// The other set is left unmodified
+	assert.Equal(t, map[int]struct{}{3: {}, 4: {}}, other.data)
+}
+
 func TestSet_Iter(t *testing.T) {
 	var actual []string
 
@@ -0,0 +1,569 @@
+package collections
+
+// SortedMap is a map implementation that keeps its entries sorted by key. It is
+// backed by a left-leaning red-black tree so Set, Get and Delete are O(log n)
+// operations, and the entries can be iterated in key order using ForEach or in
+// reverse key order using ForEachReverse.
+//
+// In addition to the usual map operations SortedMap supports navigational queries
+// such as Floor, Ceiling, Lower and Higher, range queries using Range, HeadMap and
+// TailMap, and positional queries using Rank and At which are also O(log n).
+//
+// The zero-value of SortedMap is not usable. NewSortedMap or NewSortedMapFunc
+// should be used to create and initialize a new instance of SortedMap.
+//
+// Note: SortedMap is not thread safe.
+type SortedMap[K any, V any] struct {
+	root *rbNode[K, V]
+	size int
+	cmp  func(a, b K) int
+}
+
+type rbNode[K any, V any] struct {
+	key   K
+	val   V
+	left  *rbNode[K, V]
+	right *rbNode[K, V]
+	red   bool
+	// size is the number of nodes in the subtree rooted at this node.
+	size int
+}
+
+// NewSortedMap creates and initializes a new SortedMap ordering keys by their
+// natural order.
+func NewSortedMap[K Ordered, V any]() *SortedMap[K, V] {
+	return NewSortedMapFunc[K, V](Compare[K])
+}
+
+// NewSortedMapFunc creates and initializes a new SortedMap ordering keys using the
+// provided comparator. The comparator must return a negative number if a is less
+// than b, zero if they are equal and a positive number if a is greater than b.
+// Keys the comparator considers equal are treated as the same key.
+func NewSortedMapFunc[K any, V any](cmp func(a, b K) int) *SortedMap[K, V] {
+	if cmp == nil {
+		panic("comparator cannot be nil")
+	}
+	return &SortedMap[K, V]{
+		cmp: cmp,
+	}
+}
+
+// Set inserts a new key/value into the map or replaces the value for an existing
+// key. If the key didn't exist in the map and was inserted true is returned.
+// Otherwise, if they key was already existing returns false.
+func (m *SortedMap[K, V]) Set(key K, val V) bool {
+	var inserted bool
+	m.root, inserted = m.put(m.root, key, val)
+	m.root.red = false
+	if inserted {
+		m.size++
+	}
+	return inserted
+}
+
+// Contains returns true if the given key exists in the SortedMap, otherwise
+// returns false.
+func (m *SortedMap[K, V]) Contains(key K) bool {
+	return m.find(key) != nil
+}
+
+// Get retrieves the value for a key. It follows the same idioms of the built-in
+// map. If the key doesn't exist the zero value and false value are returned.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	n := m.find(key)
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.val, true
+}
+
+// GetOrDefault retrieves the value for a key and if it doesn't exist returns the
+// provided default value.
+func (m *SortedMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if n := m.find(key); n != nil {
+		return n.val
+	}
+	return defaultValue
+}
+
+// Delete removes a key/value entry from the SortedMap. If the key didn't exist
+// returns false, otherwise returns true if the entry was deleted.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	if m.find(key) == nil {
+		return false
+	}
+	if !isRed(m.root.left) && !isRed(m.root.right) {
+		m.root.red = true
+	}
+	m.root = m.delete(m.root, key)
+	if m.root != nil {
+		m.root.red = false
+	}
+	m.size--
+	return true
+}
+
+// Min returns the entry with the smallest key. If the map is empty the zero value
+// of the key and value and false are returned.
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	if m.root == nil {
+		return nodeEntry[K, V](nil)
+	}
+	return nodeEntry(minNode(m.root))
+}
+
+// Max returns the entry with the largest key. If the map is empty the zero value
+// of the key and value and false are returned.
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	if m.root == nil {
+		return nodeEntry[K, V](nil)
+	}
+	return nodeEntry(maxNode(m.root))
+}
+
+// PopMin removes and returns the entry with the smallest key. If the map is empty
+// the zero value of the key and value and false are returned.
+func (m *SortedMap[K, V]) PopMin() (K, V, bool) {
+	key, val, ok := m.Min()
+	if ok {
+		m.Delete(key)
+	}
+	return key, val, ok
+}
+
+// PopMax removes and returns the entry with the largest key. If the map is empty
+// the zero value of the key and value and false are returned.
+func (m *SortedMap[K, V]) PopMax() (K, V, bool) {
+	key, val, ok := m.Max()
+	if ok {
+		m.Delete(key)
+	}
+	return key, val, ok
+}
+
+// Floor returns the entry with the largest key less than or equal to the given
+// key. If no such entry exists the zero value of the key and value and false are
+// returned.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	var candidate *rbNode[K, V]
+	for n := m.root; n != nil; {
+		c := m.cmp(key, n.key)
+		if c == 0 {
+			return nodeEntry(n)
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			candidate = n
+			n = n.right
+		}
+	}
+	return nodeEntry(candidate)
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to the
+// given key. If no such entry exists the zero value of the key and value and false
+// are returned.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	var candidate *rbNode[K, V]
+	for n := m.root; n != nil; {
+		c := m.cmp(key, n.key)
+		if c == 0 {
+			return nodeEntry(n)
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			candidate = n
+			n = n.left
+		}
+	}
+	return nodeEntry(candidate)
+}
+
+// Lower returns the entry with the largest key strictly less than the given key.
+// If no such entry exists the zero value of the key and value and false are
+// returned.
+func (m *SortedMap[K, V]) Lower(key K) (K, V, bool) {
+	var candidate *rbNode[K, V]
+	for n := m.root; n != nil; {
+		if m.cmp(key, n.key) <= 0 {
+			n = n.left
+		} else {
+			candidate = n
+			n = n.right
+		}
+	}
+	return nodeEntry(candidate)
+}
+
+// Higher returns the entry with the smallest key strictly greater than the given
+// key. If no such entry exists the zero value of the key and value and false are
+// returned.
+func (m *SortedMap[K, V]) Higher(key K) (K, V, bool) {
+	var candidate *rbNode[K, V]
+	for n := m.root; n != nil; {
+		if m.cmp(key, n.key) >= 0 {
+			n = n.right
+		} else {
+			candidate = n
+			n = n.left
+		}
+	}
+	return nodeEntry(candidate)
+}
+
+// Rank returns the number of keys in the map strictly less than the given key,
+// which is the 0-based position of the key in ascending order if it exists.
+func (m *SortedMap[K, V]) Rank(key K) int {
+	rank := 0
+	for n := m.root; n != nil; {
+		c := m.cmp(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += subtreeSize(n.left) + 1
+			n = n.right
+		default:
+			return rank + subtreeSize(n.left)
+		}
+	}
+	return rank
+}
+
+// At returns the entry at the given 0-based position in ascending key order. If
+// the position is out of range the zero value of the key and value and false are
+// returned.
+func (m *SortedMap[K, V]) At(i int) (K, V, bool) {
+	if i < 0 || i >= m.size {
+		return nodeEntry[K, V](nil)
+	}
+	n := m.root
+	for {
+		leftSize := subtreeSize(n.left)
+		switch {
+		case i < leftSize:
+			n = n.left
+		case i > leftSize:
+			i -= leftSize + 1
+			n = n.right
+		default:
+			return nodeEntry(n)
+		}
+	}
+}
+
+// Range iterates through the entries with keys greater than or equal to from and
+// strictly less than to in key order, passing the key/value pair to the provided
+// function. Iteration stops early if the function returns false.
+func (m *SortedMap[K, V]) Range(from K, to K, fn func(key K, val V) bool) {
+	m.rangeNode(m.root, from, to, fn)
+}
+
+// HeadMap returns a new SortedMap containing the entries with keys strictly less
+// than to. The returned SortedMap uses the same comparator and is independent of
+// this map.
+func (m *SortedMap[K, V]) HeadMap(to K) *SortedMap[K, V] {
+	head := NewSortedMapFunc[K, V](m.cmp)
+	m.ascend(m.root, func(n *rbNode[K, V]) bool {
+		if m.cmp(n.key, to) >= 0 {
+			return false
+		}
+		head.Set(n.key, n.val)
+		return true
+	})
+	return head
+}
+
+// TailMap returns a new SortedMap containing the entries with keys greater than or
+// equal to from. The returned SortedMap uses the same comparator and is
+// independent of this map.
+func (m *SortedMap[K, V]) TailMap(from K) *SortedMap[K, V] {
+	tail := NewSortedMapFunc[K, V](m.cmp)
+	m.descend(m.root, func(n *rbNode[K, V]) bool {
+		if m.cmp(n.key, from) < 0 {
+			return false
+		}
+		tail.Set(n.key, n.val)
+		return true
+	})
+	return tail
+}
+
+// Size returns the number of entries in the SortedMap.
+func (m *SortedMap[K, V]) Size() int {
+	return m.size
+}
+
+// Keys returns the keys in ascending order.
+func (m *SortedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.ascend(m.root, func(n *rbNode[K, V]) bool {
+		keys = append(keys, n.key)
+		return true
+	})
+	return keys
+}
+
+// Values returns the values in the ascending order of their keys.
+func (m *SortedMap[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	m.ascend(m.root, func(n *rbNode[K, V]) bool {
+		values = append(values, n.val)
+		return true
+	})
+	return values
+}
+
+// Clear removes all the entries from the SortedMap.
+func (m *SortedMap[K, V]) Clear() {
+	m.root = nil
+	m.size = 0
+}
+
+// ForEach iterates through the map entries in ascending key order passing the
+// key/value pair to the provided function/closure. The SortedMap must not be
+// modified while iterating.
+func (m *SortedMap[K, V]) ForEach(fn func(key K, val V)) {
+	m.ascend(m.root, func(n *rbNode[K, V]) bool {
+		fn(n.key, n.val)
+		return true
+	})
+}
+
+// ForEachReverse iterates through the map entries in descending key order passing
+// the key/value pair to the provided function/closure. The SortedMap must not be
+// modified while iterating.
+func (m *SortedMap[K, V]) ForEachReverse(fn func(key K, val V)) {
+	m.descend(m.root, func(n *rbNode[K, V]) bool {
+		fn(n.key, n.val)
+		return true
+	})
+}
+
+func (m *SortedMap[K, V]) find(key K) *rbNode[K, V] {
+	for n := m.root; n != nil; {
+		c := m.cmp(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+func (m *SortedMap[K, V]) put(h *rbNode[K, V], key K, val V) (*rbNode[K, V], bool) {
+	if h == nil {
+		return &rbNode[K, V]{key: key, val: val, red: true, size: 1}, true
+	}
+	var inserted bool
+	c := m.cmp(key, h.key)
+	switch {
+	case c < 0:
+		h.left, inserted = m.put(h.left, key, val)
+	case c > 0:
+		h.right, inserted = m.put(h.right, key, val)
+	default:
+		h.val = val
+	}
+	return balance(h), inserted
+}
+
+// delete removes the key from the subtree rooted at h. The key must exist in the
+// subtree.
+func (m *SortedMap[K, V]) delete(h *rbNode[K, V], key K) *rbNode[K, V] {
+	if m.cmp(key, h.key) < 0 {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeft(h)
+		}
+		h.left = m.delete(h.left, key)
+		return balance(h)
+	}
+	if isRed(h.left) {
+		h = rotateRight(h)
+	}
+	if m.cmp(key, h.key) == 0 && h.right == nil {
+		return nil
+	}
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = moveRedRight(h)
+	}
+	if m.cmp(key, h.key) == 0 {
+		successor := minNode(h.right)
+		h.key = successor.key
+		h.val = successor.val
+		h.right = deleteMin(h.right)
+	} else {
+		h.right = m.delete(h.right, key)
+	}
+	return balance(h)
+}
+
+func (m *SortedMap[K, V]) rangeNode(n *rbNode[K, V], from K, to K, fn func(key K, val V) bool) bool {
+	if n == nil {
+		return true
+	}
+	afterFrom := m.cmp(from, n.key) <= 0
+	beforeTo := m.cmp(n.key, to) < 0
+	if afterFrom && !m.rangeNode(n.left, from, to, fn) {
+		return false
+	}
+	if afterFrom && beforeTo && !fn(n.key, n.val) {
+		return false
+	}
+	if beforeTo {
+		return m.rangeNode(n.right, from, to, fn)
+	}
+	return true
+}
+
+// ascend walks the subtree rooted at n in ascending order until fn returns false.
+// It returns false if the walk was stopped early.
+func (m *SortedMap[K, V]) ascend(n *rbNode[K, V], fn func(n *rbNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return m.ascend(n.left, fn) && fn(n) && m.ascend(n.right, fn)
+}
+
+// ascendFrom walks the nodes of the subtree rooted at n with keys greater than or
+// equal to from in ascending order until fn returns false. It returns false if
+// the walk was stopped early.
+func (m *SortedMap[K, V]) ascendFrom(n *rbNode[K, V], from K, fn func(n *rbNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	if m.cmp(from, n.key) <= 0 {
+		if !m.ascendFrom(n.left, from, fn) || !fn(n) {
+			return false
+		}
+	}
+	return m.ascendFrom(n.right, from, fn)
+}
+
+// descend walks the subtree rooted at n in descending order until fn returns
+// false. It returns false if the walk was stopped early.
+func (m *SortedMap[K, V]) descend(n *rbNode[K, V], fn func(n *rbNode[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return m.descend(n.right, fn) && fn(n) && m.descend(n.left, fn)
+}
+
+func nodeEntry[K any, V any](n *rbNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return n.key, n.val, true
+}
+
+func isRed[K any, V any](n *rbNode[K, V]) bool {
+	return n != nil && n.red
+}
+
+func subtreeSize[K any, V any](n *rbNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func rotateLeft[K any, V any](h *rbNode[K, V]) *rbNode[K, V] {
+	x := h.right
+	h.right = x.left
+	x.left = h
+	x.red = h.red
+	h.red = true
+	x.size = h.size
+	h.size = 1 + subtreeSize(h.left) + subtreeSize(h.right)
+	return x
+}
+
+func rotateRight[K any, V any](h *rbNode[K, V]) *rbNode[K, V] {
+	x := h.left
+	h.left = x.right
+	x.right = h
+	x.red = h.red
+	h.red = true
+	x.size = h.size
+	h.size = 1 + subtreeSize(h.left) + subtreeSize(h.right)
+	return x
+}
+
+func flipColors[K any, V any](h *rbNode[K, V]) {
+	h.red = !h.red
+	h.left.red = !h.left.red
+	h.right.red = !h.right.red
+}
+
+// balance restores the left-leaning red-black invariants, and the subtree size, on
+// the way back up the tree after an insert or delete.
+func balance[K any, V any](h *rbNode[K, V]) *rbNode[K, V] {
+	h.size = 1 + subtreeSize(h.left) + subtreeSize(h.right)
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeft(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRight(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+	return h
+}
+
+// moveRedLeft makes h.left or one of its children red, assuming h is red and both
+// h.left and h.left.left are black.
+func moveRedLeft[K any, V any](h *rbNode[K, V]) *rbNode[K, V] {
+	flipColors(h)
+	if isRed(h.right.left) {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// moveRedRight makes h.right or one of its children red, assuming h is red and
+// both h.right and h.right.left are black.
+func moveRedRight[K any, V any](h *rbNode[K, V]) *rbNode[K, V] {
+	flipColors(h)
+	if isRed(h.left.left) {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+	return h
+}
+
+func deleteMin[K any, V any](h *rbNode[K, V]) *rbNode[K, V] {
+	if h.left == nil {
+		return nil
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeft(h)
+	}
+	h.left = deleteMin(h.left)
+	return balance(h)
+}
+
+func minNode[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func maxNode[K any, V any](n *rbNode[K, V]) *rbNode[K, V] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
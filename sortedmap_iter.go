@@ -0,0 +1,29 @@
+//go:build go1.23
+
+package collections
+
+import (
+	"iter"
+)
+
+// All returns an iterator over the key/value pairs of the map in ascending key
+// order, for use with range-over-func. The SortedMap must not be modified while
+// ranging.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, func(n *rbNode[K, V]) bool {
+			return yield(n.key, n.val)
+		})
+	}
+}
+
+// Backward returns an iterator over the key/value pairs of the map in descending
+// key order, for use with range-over-func. The SortedMap must not be modified
+// while ranging.
+func (m *SortedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.descend(m.root, func(n *rbNode[K, V]) bool {
+			return yield(n.key, n.val)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+//go:build go1.23
+
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_All(t *testing.T) {
+	sm := NewSortedMap[int, string]()
+	sm.Set(2, "two")
+	sm.Set(3, "three")
+	sm.Set(1, "one")
+
+	var keys []int
+	var vals []string
+	for key, val := range sm.All() {
+		keys = append(keys, key)
+		vals = append(vals, val)
+	}
+	assert.Equal(t, []int{1, 2, 3}, keys)
+	assert.Equal(t, []string{"one", "two", "three"}, vals)
+
+	keys = nil
+	for key := range sm.All() {
+		if key == 2 {
+			break
+		}
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []int{1}, keys)
+}
+
+func TestSortedMap_Backward(t *testing.T) {
+	sm := NewSortedMap[int, string]()
+	sm.Set(2, "two")
+	sm.Set(3, "three")
+	sm.Set(1, "one")
+
+	var keys []int
+	for key := range sm.Backward() {
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []int{3, 2, 1}, keys)
+}
@@ -0,0 +1,255 @@
+package collections
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap(t *testing.T) {
+	sm := NewSortedMap[string, int]()
+	assert.True(t, sm.Set("banana", 2))
+	assert.True(t, sm.Set("apple", 1))
+	assert.True(t, sm.Set("cherry", 3))
+	assert.False(t, sm.Set("apple", 11))
+
+	assert.Equal(t, 3, sm.Size())
+	assert.Equal(t, []string{"apple", "banana", "cherry"}, sm.Keys())
+	assert.Equal(t, []int{11, 2, 3}, sm.Values())
+
+	val, ok := sm.Get("apple")
+	assert.True(t, ok)
+	assert.Equal(t, 11, val)
+	_, ok = sm.Get("durian")
+	assert.False(t, ok)
+	assert.Equal(t, 42, sm.GetOrDefault("durian", 42))
+	assert.True(t, sm.Contains("cherry"))
+
+	assert.True(t, sm.Delete("banana"))
+	assert.False(t, sm.Delete("banana"))
+	assert.Equal(t, []string{"apple", "cherry"}, sm.Keys())
+
+	sm.Clear()
+	assert.Equal(t, 0, sm.Size())
+	assert.Empty(t, sm.Keys())
+}
+
+func TestNewSortedMapFunc(t *testing.T) {
+	sm := NewSortedMapFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	sm.Set("b", 1)
+	sm.Set("A", 2)
+	sm.Set("a", 3)
+	assert.Equal(t, []string{"A", "b"}, sm.Keys())
+	assert.Equal(t, 3, sm.GetOrDefault("a", 0))
+
+	assert.Panics(t, func() {
+		NewSortedMapFunc[string, int](nil)
+	})
+}
+
+func TestSortedMap_MinMax(t *testing.T) {
+	sm := NewSortedMap[int, string]()
+	_, _, ok := sm.Min()
+	assert.False(t, ok)
+	_, _, ok = sm.PopMax()
+	assert.False(t, ok)
+
+	for _, k := range []int{5, 1, 9, 3} {
+		sm.Set(k, "")
+	}
+	key, _, ok := sm.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, key)
+	key, _, ok = sm.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 9, key)
+
+	key, _, _ = sm.PopMin()
+	assert.Equal(t, 1, key)
+	key, _, _ = sm.PopMax()
+	assert.Equal(t, 9, key)
+	assert.Equal(t, []int{3, 5}, sm.Keys())
+}
+
+func TestSortedMap_Navigation(t *testing.T) {
+	sm := NewSortedMap[int, int]()
+	for _, k := range []int{10, 20, 30} {
+		sm.Set(k, k*10)
+	}
+
+	tests := []struct {
+		name string
+		fn   func(int) (int, int, bool)
+		key  int
+		want int
+		ok   bool
+	}{
+		{"floor exact", sm.Floor, 20, 20, true},
+		{"floor between", sm.Floor, 25, 20, true},
+		{"floor below", sm.Floor, 5, 0, false},
+		{"ceiling exact", sm.Ceiling, 20, 20, true},
+		{"ceiling between", sm.Ceiling, 25, 30, true},
+		{"ceiling above", sm.Ceiling, 35, 0, false},
+		{"lower exact", sm.Lower, 20, 10, true},
+		{"lower between", sm.Lower, 25, 20, true},
+		{"lower smallest", sm.Lower, 10, 0, false},
+		{"higher exact", sm.Higher, 20, 30, true},
+		{"higher between", sm.Higher, 15, 20, true},
+		{"higher largest", sm.Higher, 30, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, val, ok := test.fn(test.key)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.want, key)
+			assert.Equal(t, test.want*10, val)
+		})
+	}
+}
+
+func TestSortedMap_Range(t *testing.T) {
+	sm := NewSortedMap[int, int]()
+	for i := 0; i < 10; i++ {
+		sm.Set(i, i)
+	}
+
+	var keys []int
+	sm.Range(3, 7, func(key int, val int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{3, 4, 5, 6}, keys)
+
+	keys = nil
+	sm.Range(3, 7, func(key int, val int) bool {
+		keys = append(keys, key)
+		return key < 4
+	})
+	assert.Equal(t, []int{3, 4}, keys)
+
+	keys = nil
+	sm.Range(7, 3, func(key int, val int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Empty(t, keys)
+
+	assert.Equal(t, []int{0, 1, 2}, sm.HeadMap(3).Keys())
+	assert.Equal(t, []int{7, 8, 9}, sm.TailMap(7).Keys())
+	assert.Equal(t, 0, sm.HeadMap(0).Size())
+
+	tail := sm.TailMap(8)
+	tail.Set(100, 100)
+	assert.False(t, sm.Contains(100))
+}
+
+func TestSortedMap_RankAt(t *testing.T) {
+	sm := NewSortedMap[int, int]()
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		sm.Set(k, k)
+	}
+
+	for i, k := range []int{10, 20, 30, 40, 50} {
+		assert.Equal(t, i, sm.Rank(k))
+		key, val, ok := sm.At(i)
+		assert.True(t, ok)
+		assert.Equal(t, k, key)
+		assert.Equal(t, k, val)
+	}
+	assert.Equal(t, 0, sm.Rank(5))
+	assert.Equal(t, 2, sm.Rank(25))
+	assert.Equal(t, 5, sm.Rank(55))
+
+	_, _, ok := sm.At(-1)
+	assert.False(t, ok)
+	_, _, ok = sm.At(5)
+	assert.False(t, ok)
+
+	sm.Delete(30)
+	assert.Equal(t, 2, sm.Rank(40))
+	key, _, _ := sm.At(2)
+	assert.Equal(t, 40, key)
+}
+
+func TestSortedMap_ForEach(t *testing.T) {
+	sm := NewSortedMap[int, int]()
+	for _, k := range []int{3, 1, 2} {
+		sm.Set(k, k)
+	}
+
+	var keys []int
+	sm.ForEach(func(key int, val int) {
+		keys = append(keys, key)
+	})
+	assert.Equal(t, []int{1, 2, 3}, keys)
+
+	keys = nil
+	sm.ForEachReverse(func(key int, val int) {
+		keys = append(keys, key)
+	})
+	assert.Equal(t, []int{3, 2, 1}, keys)
+}
+
+func TestSortedMap_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	sm := NewSortedMap[int, int]()
+	reference := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(500)
+		if rng.Intn(3) == 0 {
+			_, exists := reference[key]
+			assert.Equal(t, exists, sm.Delete(key))
+			delete(reference, key)
+		} else {
+			_, exists := reference[key]
+			assert.Equal(t, !exists, sm.Set(key, i))
+			reference[key] = i
+		}
+		if i%250 == 0 {
+			assertValidRedBlackTree(t, sm)
+		}
+	}
+
+	keys := make([]int, 0, len(reference))
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	assert.Equal(t, keys, sm.Keys())
+	assert.Equal(t, len(reference), sm.Size())
+	for key, val := range reference {
+		assert.Equal(t, val, sm.GetOrDefault(key, -1))
+	}
+	assertValidRedBlackTree(t, sm)
+}
+
+// assertValidRedBlackTree checks the root is black, no red node has a red child,
+// red links lean left and every path has the same number of black nodes.
+func assertValidRedBlackTree[K any, V any](t *testing.T, sm *SortedMap[K, V]) {
+	t.Helper()
+	assert.False(t, isRed(sm.root))
+
+	var blackHeight func(n *rbNode[K, V]) int
+	blackHeight = func(n *rbNode[K, V]) int {
+		if n == nil {
+			return 1
+		}
+		assert.False(t, isRed(n.right), "right leaning red link")
+		assert.False(t, isRed(n) && isRed(n.left), "consecutive red links")
+		left := blackHeight(n.left)
+		right := blackHeight(n.right)
+		assert.Equal(t, left, right, "unbalanced black height")
+		assert.Equal(t, 1+subtreeSize(n.left)+subtreeSize(n.right), n.size, "incorrect subtree size")
+		if n.red {
+			return left
+		}
+		return left + 1
+	}
+	blackHeight(sm.root)
+}
@@ -0,0 +1,231 @@
+package collections
+
+// SortedSet is a collection that contains no duplicate elements and keeps its
+// elements sorted. Elements are ordered by their natural order or by a comparator.
+//
+// SortedSet is backed by an order-statistic red-black tree, so in addition to Add,
+// Remove and Contains the position of an element can be found with Rank, and the
+// element at a position with At, in O(log n). This gives SortedSet semantics
+// similar to a Redis sorted set, for example for leaderboards.
+//
+// The zero-value of SortedSet is not usable. NewSortedSet or NewSortedSetFunc
+// should be used to create and initialize a new instance of SortedSet.
+//
+// Note: SortedSet is not thread safe.
+type SortedSet[T any] struct {
+	data *SortedMap[T, struct{}]
+}
+
+// NewSortedSet creates and initializes a new SortedSet ordering elements by their
+// natural order.
+func NewSortedSet[T Ordered]() *SortedSet[T] {
+	return NewSortedSetFunc[T](Compare[T])
+}
+
+// NewSortedSetFunc creates and initializes a new SortedSet ordering elements using
+// the provided comparator. The comparator must return a negative number if a is
+// less than b, zero if they are equal and a positive number if a is greater than
+// b. Elements the comparator considers equal are treated as duplicates.
+func NewSortedSetFunc[T any](cmp func(a, b T) int) *SortedSet[T] {
+	return &SortedSet[T]{
+		data: NewSortedMapFunc[T, struct{}](cmp),
+	}
+}
+
+// Add adds the elements into the SortedSet. If an element already exists it is
+// effectively a no-op.
+func (s *SortedSet[T]) Add(vals ...T) {
+	for _, val := range vals {
+		s.data.Set(val, struct{}{})
+	}
+}
+
+// Remove removes/deletes an element from the SortedSet. If the element doesn't
+// exist this is a no-op.
+func (s *SortedSet[T]) Remove(val T) {
+	s.data.Delete(val)
+}
+
+// Contains returns a boolean value indicating if the provided value is in the
+// SortedSet.
+func (s *SortedSet[T]) Contains(val T) bool {
+	return s.data.Contains(val)
+}
+
+// Size returns the current number of elements in the SortedSet.
+func (s *SortedSet[T]) Size() int {
+	return s.data.Size()
+}
+
+// First returns the smallest element. If the SortedSet is empty the zero value
+// and false are returned.
+func (s *SortedSet[T]) First() (T, bool) {
+	val, _, ok := s.data.Min()
+	return val, ok
+}
+
+// Last returns the largest element. If the SortedSet is empty the zero value and
+// false are returned.
+func (s *SortedSet[T]) Last() (T, bool) {
+	val, _, ok := s.data.Max()
+	return val, ok
+}
+
+// PollFirst removes and returns the smallest element. If the SortedSet is empty
+// the zero value and false are returned.
+func (s *SortedSet[T]) PollFirst() (T, bool) {
+	val, _, ok := s.data.PopMin()
+	return val, ok
+}
+
+// PollLast removes and returns the largest element. If the SortedSet is empty the
+// zero value and false are returned.
+func (s *SortedSet[T]) PollLast() (T, bool) {
+	val, _, ok := s.data.PopMax()
+	return val, ok
+}
+
+// Floor returns the largest element less than or equal to the given value. If no
+// such element exists the zero value and false are returned.
+func (s *SortedSet[T]) Floor(val T) (T, bool) {
+	floor, _, ok := s.data.Floor(val)
+	return floor, ok
+}
+
+// Ceiling returns the smallest element greater than or equal to the given value.
+// If no such element exists the zero value and false are returned.
+func (s *SortedSet[T]) Ceiling(val T) (T, bool) {
+	ceiling, _, ok := s.data.Ceiling(val)
+	return ceiling, ok
+}
+
+// Lower returns the largest element strictly less than the given value. If no such
+// element exists the zero value and false are returned.
+func (s *SortedSet[T]) Lower(val T) (T, bool) {
+	lower, _, ok := s.data.Lower(val)
+	return lower, ok
+}
+
+// Higher returns the smallest element strictly greater than the given value. If no
+// such element exists the zero value and false are returned.
+func (s *SortedSet[T]) Higher(val T) (T, bool) {
+	higher, _, ok := s.data.Higher(val)
+	return higher, ok
+}
+
+// Rank returns the 0-based position of the element in ascending order and true.
+// If the element doesn't exist the position it would be inserted at, which is the
+// number of elements less than it, and false are returned.
+func (s *SortedSet[T]) Rank(val T) (int, bool) {
+	return s.data.Rank(val), s.data.Contains(val)
+}
+
+// At returns the element at the given 0-based position in ascending order. If the
+// position is out of range the zero value and false are returned.
+func (s *SortedSet[T]) At(rank int) (T, bool) {
+	val, _, ok := s.data.At(rank)
+	return val, ok
+}
+
+// RangeByRank returns the elements with a position between start and stop, both
+// inclusive, in ascending order. Like Redis ZRANGE negative positions count back
+// from the largest element, so -1 is the largest element and -2 the one before
+// it. Out of range positions are clamped and an empty slice is returned if start
+// is after stop.
+func (s *SortedSet[T]) RangeByRank(start int, stop int) []T {
+	size := s.data.Size()
+	if start < 0 {
+		start += size
+	}
+	if stop < 0 {
+		stop += size
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= size {
+		stop = size - 1
+	}
+	if start > stop {
+		return []T{}
+	}
+
+	from, _, _ := s.data.At(start)
+	vals := make([]T, 0, stop-start+1)
+	s.data.ascendFrom(s.data.root, from, func(n *rbNode[T, struct{}]) bool {
+		vals = append(vals, n.key)
+		return len(vals) < cap(vals)
+	})
+	return vals
+}
+
+// RangeByValue returns the elements greater than or equal to from and less than or
+// equal to to in ascending order.
+func (s *SortedSet[T]) RangeByValue(from T, to T) []T {
+	vals := make([]T, 0)
+	s.data.ascendFrom(s.data.root, from, func(n *rbNode[T, struct{}]) bool {
+		if s.data.cmp(n.key, to) > 0 {
+			return false
+		}
+		vals = append(vals, n.key)
+		return true
+	})
+	return vals
+}
+
+// ForEach iterates through the SortedSet in ascending order passing the value to
+// the provided function. The SortedSet must not be modified while iterating.
+func (s *SortedSet[T]) ForEach(fn func(val T)) {
+	s.data.ForEach(func(key T, _ struct{}) {
+		fn(key)
+	})
+}
+
+// AsSlice returns the elements of the SortedSet as a slice in ascending order.
+func (s *SortedSet[T]) AsSlice() []T {
+	return s.data.Keys()
+}
+
+// Union returns a new SortedSet containing the elements in either this SortedSet
+// or the provided SortedSet. The returned SortedSet uses the comparator of this
+// SortedSet, both sets are expected to use the same ordering.
+func (s *SortedSet[T]) Union(other *SortedSet[T]) *SortedSet[T] {
+	result := NewSortedSetFunc[T](s.data.cmp)
+	s.ForEach(func(val T) {
+		result.Add(val)
+	})
+	other.ForEach(func(val T) {
+		result.Add(val)
+	})
+	return result
+}
+
+// Intersection returns a new SortedSet containing the elements in both this
+// SortedSet and the provided SortedSet. The returned SortedSet uses the comparator
+// of this SortedSet, both sets are expected to use the same ordering.
+func (s *SortedSet[T]) Intersection(other *SortedSet[T]) *SortedSet[T] {
+	result := NewSortedSetFunc[T](s.data.cmp)
+	smaller, larger := s, other
+	if smaller.Size() > larger.Size() {
+		smaller, larger = larger, smaller
+	}
+	smaller.ForEach(func(val T) {
+		if larger.Contains(val) {
+			result.Add(val)
+		}
+	})
+	return result
+}
+
+// Difference returns a new SortedSet containing the elements in this SortedSet
+// which are not in the provided SortedSet. The returned SortedSet uses the
+// comparator of this SortedSet, both sets are expected to use the same ordering.
+func (s *SortedSet[T]) Difference(other *SortedSet[T]) *SortedSet[T] {
+	result := NewSortedSetFunc[T](s.data.cmp)
+	s.ForEach(func(val T) {
+		if !other.Contains(val) {
+			result.Add(val)
+		}
+	})
+	return result
+}
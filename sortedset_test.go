@@ -0,0 +1,166 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedSet(t *testing.T) {
+	s := NewSortedSet[int]()
+	s.Add(30, 10, 20, 10)
+
+	assert.Equal(t, 3, s.Size())
+	assert.Equal(t, []int{10, 20, 30}, s.AsSlice())
+	assert.True(t, s.Contains(20))
+
+	s.Remove(20)
+	s.Remove(100)
+	assert.False(t, s.Contains(20))
+	assert.Equal(t, []int{10, 30}, s.AsSlice())
+
+	var vals []int
+	s.ForEach(func(val int) {
+		vals = append(vals, val)
+	})
+	assert.Equal(t, []int{10, 30}, vals)
+}
+
+func TestNewSortedSetFunc(t *testing.T) {
+	type player struct {
+		name  string
+		score int
+	}
+	// Order by score descending, like a leaderboard
+	leaderboard := NewSortedSetFunc(func(a, b player) int {
+		if c := Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return Compare(a.name, b.name)
+	})
+	leaderboard.Add(player{"alice", 50}, player{"bob", 70}, player{"carol", 60})
+
+	top, ok := leaderboard.First()
+	assert.True(t, ok)
+	assert.Equal(t, "bob", top.name)
+
+	rank, ok := leaderboard.Rank(player{"carol", 60})
+	assert.True(t, ok)
+	assert.Equal(t, 1, rank)
+}
+
+func TestSortedSet_FirstLast(t *testing.T) {
+	s := NewSortedSet[string]()
+	_, ok := s.First()
+	assert.False(t, ok)
+	_, ok = s.PollLast()
+	assert.False(t, ok)
+
+	s.Add("b", "c", "a")
+	first, _ := s.First()
+	last, _ := s.Last()
+	assert.Equal(t, "a", first)
+	assert.Equal(t, "c", last)
+
+	first, ok = s.PollFirst()
+	assert.True(t, ok)
+	assert.Equal(t, "a", first)
+	last, ok = s.PollLast()
+	assert.True(t, ok)
+	assert.Equal(t, "c", last)
+	assert.Equal(t, []string{"b"}, s.AsSlice())
+}
+
+func TestSortedSet_Navigation(t *testing.T) {
+	s := NewSortedSet[int]()
+	s.Add(10, 20, 30)
+
+	val, ok := s.Floor(25)
+	assert.True(t, ok)
+	assert.Equal(t, 20, val)
+	val, ok = s.Ceiling(25)
+	assert.True(t, ok)
+	assert.Equal(t, 30, val)
+	val, ok = s.Lower(20)
+	assert.True(t, ok)
+	assert.Equal(t, 10, val)
+	val, ok = s.Higher(20)
+	assert.True(t, ok)
+	assert.Equal(t, 30, val)
+
+	_, ok = s.Floor(5)
+	assert.False(t, ok)
+	_, ok = s.Higher(30)
+	assert.False(t, ok)
+}
+
+func TestSortedSet_RankAt(t *testing.T) {
+	s := NewSortedSet[int]()
+	s.Add(10, 20, 30, 40)
+
+	rank, ok := s.Rank(30)
+	assert.True(t, ok)
+	assert.Equal(t, 2, rank)
+	rank, ok = s.Rank(25)
+	assert.False(t, ok)
+	assert.Equal(t, 2, rank)
+
+	val, ok := s.At(0)
+	assert.True(t, ok)
+	assert.Equal(t, 10, val)
+	_, ok = s.At(4)
+	assert.False(t, ok)
+}
+
+func TestSortedSet_RangeByRank(t *testing.T) {
+	s := NewSortedSet[int]()
+	s.Add(10, 20, 30, 40, 50)
+
+	tests := []struct {
+		name        string
+		start, stop int
+		want        []int
+	}{
+		{"all", 0, -1, []int{10, 20, 30, 40, 50}},
+		{"middle", 1, 3, []int{20, 30, 40}},
+		{"single", 2, 2, []int{30}},
+		{"negative", -2, -1, []int{40, 50}},
+		{"clamped", -10, 10, []int{10, 20, 30, 40, 50}},
+		{"start after stop", 3, 1, []int{}},
+		{"out of range", 5, 10, []int{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, s.RangeByRank(test.start, test.stop))
+		})
+	}
+	assert.Equal(t, []int{}, NewSortedSet[int]().RangeByRank(0, -1))
+}
+
+func TestSortedSet_RangeByValue(t *testing.T) {
+	s := NewSortedSet[int]()
+	s.Add(10, 20, 30, 40, 50)
+
+	assert.Equal(t, []int{20, 30, 40}, s.RangeByValue(20, 40))
+	assert.Equal(t, []int{20, 30}, s.RangeByValue(15, 35))
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, s.RangeByValue(0, 100))
+	assert.Equal(t, []int{}, s.RangeByValue(41, 49))
+	assert.Equal(t, []int{}, s.RangeByValue(40, 20))
+}
+
+func TestSortedSet_Algebra(t *testing.T) {
+	s1 := NewSortedSet[int]()
+	s1.Add(1, 2, 3, 4)
+	s2 := NewSortedSet[int]()
+	s2.Add(3, 4, 5)
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s1.Union(s2).AsSlice())
+	assert.Equal(t, []int{3, 4}, s1.Intersection(s2).AsSlice())
+	assert.Equal(t, []int{3, 4}, s2.Intersection(s1).AsSlice())
+	assert.Equal(t, []int{1, 2}, s1.Difference(s2).AsSlice())
+	assert.Equal(t, []int{5}, s2.Difference(s1).AsSlice())
+
+	// The operands are left unmodified
+	assert.Equal(t, []int{1, 2, 3, 4}, s1.AsSlice())
+	assert.Equal(t, []int{3, 4, 5}, s2.AsSlice())
+}
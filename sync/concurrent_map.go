@@ -1,8 +1,15 @@
 package sync
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 // DefaultShards is the default shards a ConcurrentMap will use.
@@ -75,12 +82,28 @@ type mapShard[K comparable, V any] struct {
 // ConcurrentMap is a thread safe sharded map implementation. ConcurrentMap shards
 // data to reduce lock contention.
 //
-// The zero-value of ConcurrentMap is not usable. Instead, NewConcurrentMap function
-// should be used to crete and initialize a new ConcurrentMap.
+// The zero-value of ConcurrentMap is an empty map ready to use. It is lazily
+// initialized on first use with DefaultShards shards and a MaphashHasher. The
+// NewConcurrentMap function can be used to create a ConcurrentMap with a specific
+// number of shards and Hasher.
+//
+// A ConcurrentMap only holds a reference to its shards, so once initialized,
+// copies of a ConcurrentMap share the same entries, like the built-in map. This
+// allows a ConcurrentMap to be held and marshaled by value. Copies of a
+// zero-value ConcurrentMap made before its first use are independent maps.
 type ConcurrentMap[K comparable, V any] struct {
+	// state points to the mapState[K, V] holding the shards. It is set by
+	// NewConcurrentMap, or on first use of a zero-value ConcurrentMap, and is
+	// accessed atomically.
+	state unsafe.Pointer
+}
+
+// mapState is the shared state of a ConcurrentMap.
+type mapState[K comparable, V any] struct {
 	shards     []*mapShard[K, V]
 	hasher     Hasher[K]
 	shardCount uint
+	watchers   *watcherRegistry[K, V]
 }
 
 // NewConcurrentMap creates and initializes a new empty ConcurrentMap. NewConcurrentMap
@@ -94,6 +117,21 @@ func NewConcurrentMap[K comparable, V any](shards int, hasher Hasher[K]) Concurr
 	if hasher == nil {
 		panic("illegal use of API, cannot use ConcurrentMap with nil Hasher")
 	}
+	return ConcurrentMap[K, V]{
+		state: unsafe.Pointer(newMapState[K, V](shards, hasher)),
+	}
+}
+
+func newMapState[K comparable, V any](shards int, hasher Hasher[K]) *mapState[K, V] {
+	return &mapState[K, V]{
+		shards:     newMapShards[K, V](shards),
+		hasher:     hasher,
+		shardCount: uint(shards),
+		watchers:   newWatcherRegistry[K, V](),
+	}
+}
+
+func newMapShards[K comparable, V any](shards int) []*mapShard[K, V] {
 	mapShards := make([]*mapShard[K, V], shards)
 	for i := range mapShards {
 		mapShards[i] = &mapShard[K, V]{
@@ -101,53 +139,95 @@ func NewConcurrentMap[K comparable, V any](shards int, hasher Hasher[K]) Concurr
 			RWMutex: sync.RWMutex{},
 		}
 	}
-	return ConcurrentMap[K, V]{
-		shards:     mapShards,
-		hasher:     hasher,
-		shardCount: uint(shards),
+	return mapShards
+}
+
+// lazyInit returns the state of the ConcurrentMap, initializing a zero-value
+// ConcurrentMap with the default number of shards and a MaphashHasher.
+func (m *ConcurrentMap[K, V]) lazyInit() *mapState[K, V] {
+	if s := m.load(); s != nil {
+		return s
 	}
+	s := newMapState[K, V](DefaultShards, MaphashHasher[K]())
+	if atomic.CompareAndSwapPointer(&m.state, nil, unsafe.Pointer(s)) {
+		return s
+	}
+	// Another goroutine initialized the ConcurrentMap first
+	return m.load()
+}
+
+// load returns the state of the ConcurrentMap, or nil if the ConcurrentMap is an
+// uninitialized zero-value.
+func (m *ConcurrentMap[K, V]) load() *mapState[K, V] {
+	return (*mapState[K, V])(atomic.LoadPointer(&m.state))
 }
 
 // Get retrieves a single element from the ConcurrentMap. Get follows the same
 // semantics of the built-in map returning the value and a boolean indicating
 // if the key exists.
-func (m ConcurrentMap[K, V]) Get(key K) (V, bool) {
-	shard := m.getShard(key)
+func (m *ConcurrentMap[K, V]) Get(key K) (V, bool) {
+	s := m.lazyInit()
+	shard := s.getShard(key)
 	shard.RLock()
 	val, ok := shard.data[key]
 	shard.RUnlock()
 	return val, ok
 }
 
-// MGet fetches multiple keys and returns the values as a slice.
+// MGet fetches multiple keys and returns the values as a slice. Keys that don't
+// exist in the ConcurrentMap are omitted from the returned slice, use MGetMap if
+// you need to know which value belongs to which key.
 //
-// MGet takes a pessimistic approach and acquires a read lock on all shards in
-// the ConcurrentMap before fetching keys. After all the keys have been fetched
-// the locks are released. This might lead to lock contention in cases where
-// there are heavy writes.
-func (m ConcurrentMap[K, V]) MGet(keys ...K) []V {
-	// Since we don't know which shards the keys might reside in without iterating
-	// over all the keys we pessimistically acquire a read lock on all shards.
-	// Notes: Many IDEs and linters may complain about defer in a for loop but in
-	// this case we want to defer unlocking until we are completely done fetching
-	// all keys.
-	for i := range m.shards {
-		m.shards[i].RLock()
-		defer m.shards[i].RUnlock()
+// The keys are grouped by shard so that each shard's read lock is acquired only
+// once. Since the shards are read one at a time the result is not guaranteed to
+// be a consistent snapshot across shards.
+func (m *ConcurrentMap[K, V]) MGet(keys ...K) []V {
+	s := m.lazyInit()
+	vals := make([]V, len(keys))
+	found := make([]bool, len(keys))
+	for idx, positions := range s.groupByShard(keys) {
+		shard := s.shards[idx]
+		shard.RLock()
+		for _, pos := range positions {
+			vals[pos], found[pos] = shard.data[keys[pos]]
+		}
+		shard.RUnlock()
 	}
+
 	values := make([]V, 0, len(keys))
-	for _, key := range keys {
-		shard := m.getShard(key)
-		if val, ok := shard.data[key]; ok {
-			values = append(values, val)
+	for i := range vals {
+		if found[i] {
+			values = append(values, vals[i])
+		}
+	}
+	return values
+}
+
+// MGetMap fetches multiple keys and returns the key/value pairs as a map. Keys
+// that don't exist in the ConcurrentMap will not be present in the returned map.
+//
+// Like MGet, the keys are grouped by shard so that each shard's read lock is
+// acquired only once.
+func (m *ConcurrentMap[K, V]) MGetMap(keys ...K) map[K]V {
+	s := m.lazyInit()
+	values := make(map[K]V, len(keys))
+	for idx, positions := range s.groupByShard(keys) {
+		shard := s.shards[idx]
+		shard.RLock()
+		for _, pos := range positions {
+			if val, ok := shard.data[keys[pos]]; ok {
+				values[keys[pos]] = val
+			}
 		}
+		shard.RUnlock()
 	}
 	return values
 }
 
 // Contains returns a boolean indicating if the key exists.
-func (m ConcurrentMap[K, V]) Contains(key K) bool {
-	shard := m.getShard(key)
+func (m *ConcurrentMap[K, V]) Contains(key K) bool {
+	s := m.lazyInit()
+	shard := s.getShard(key)
 	shard.RLock()
 	_, ok := shard.data[key]
 	shard.RUnlock()
@@ -156,21 +236,26 @@ func (m ConcurrentMap[K, V]) Contains(key K) bool {
 
 // Set inserts or updates ConcurrentMap by setting the key value pair. If the key
 // already exists its value is overridden.
-func (m ConcurrentMap[K, V]) Set(key K, val V) {
-	shard := m.getShard(key)
+func (m *ConcurrentMap[K, V]) Set(key K, val V) {
+	s := m.lazyInit()
+	shard := s.getShard(key)
 	shard.Lock()
+	old, existed := shard.data[key]
 	shard.data[key] = val
+	s.watchers.emit(EventSet, key, old, val, existed)
 	shard.Unlock()
 }
 
 // SetIfPresent sets the value for a given key only if they key already exists
 // in the ConcurrentMap. This is essentially an update only operation.
-func (m ConcurrentMap[K, V]) SetIfPresent(key K, val V) bool {
-	shard := m.getShard(key)
+func (m *ConcurrentMap[K, V]) SetIfPresent(key K, val V) bool {
+	s := m.lazyInit()
+	shard := s.getShard(key)
 	shard.Lock()
 	defer shard.Unlock()
-	if _, ok := shard.data[key]; ok {
+	if old, ok := shard.data[key]; ok {
 		shard.data[key] = val
+		s.watchers.emit(EventSet, key, old, val, true)
 		return true
 	}
 	return false
@@ -178,12 +263,14 @@ func (m ConcurrentMap[K, V]) SetIfPresent(key K, val V) bool {
 
 // SetIfAbsent set the value for a given key only if the key doesn't already
 // exist in the ConcurrentMap. This is essentially a insert only operation.
-func (m ConcurrentMap[K, V]) SetIfAbsent(key K, val V) bool {
-	shard := m.getShard(key)
+func (m *ConcurrentMap[K, V]) SetIfAbsent(key K, val V) bool {
+	s := m.lazyInit()
+	shard := s.getShard(key)
 	shard.Lock()
 	defer shard.Unlock()
-	if _, ok := shard.data[key]; !ok {
+	if old, ok := shard.data[key]; !ok {
 		shard.data[key] = val
+		s.watchers.emit(EventSet, key, old, val, false)
 		return true
 	}
 	return false
@@ -191,11 +278,14 @@ func (m ConcurrentMap[K, V]) SetIfAbsent(key K, val V) bool {
 
 // MSet performs a Set operation on multiple key-value paris supplied
 // as a map.
-func (m ConcurrentMap[K, V]) MSet(data map[K]V) {
+func (m *ConcurrentMap[K, V]) MSet(data map[K]V) {
+	s := m.lazyInit()
 	for key, val := range data {
-		shard := m.getShard(key)
+		shard := s.getShard(key)
 		shard.Lock()
+		old, existed := shard.data[key]
 		shard.data[key] = val
+		s.watchers.emit(EventSet, key, old, val, existed)
 		shard.Unlock()
 	}
 }
@@ -217,51 +307,143 @@ type UpsertFunc[V any] func(exist bool, currentValue V, newValue V) V
 //
 // Important: If the UpsertFunc trys to access the ConcurrentMap it may lead to
 // a deadlock because locks in Go are not reentrant.
-func (m ConcurrentMap[K, V]) Upsert(key K, val V, fn UpsertFunc[V]) V {
-	shard := m.getShard(key)
+func (m *ConcurrentMap[K, V]) Upsert(key K, val V, fn UpsertFunc[V]) V {
+	s := m.lazyInit()
+	shard := s.getShard(key)
 	shard.Lock()
 	defer shard.Unlock()
 
 	existingValue, exists := shard.data[key]
 	res := fn(exists, existingValue, val)
 	shard.data[key] = res
+	s.watchers.emit(EventUpsert, key, existingValue, res, exists)
 	return res
 }
 
 // Delete deletes a single key/value from the ConcurrentMap returning
 // a boolean indicating if the key was present or not.
-func (m ConcurrentMap[K, V]) Delete(key K) bool {
-	shard := m.getShard(key)
+func (m *ConcurrentMap[K, V]) Delete(key K) bool {
+	s := m.lazyInit()
+	shard := s.getShard(key)
 	shard.Lock()
 	defer shard.Unlock()
-	_, ok := shard.data[key]
+	old, ok := shard.data[key]
 	if !ok {
 		return false
 	}
 	delete(shard.data, key)
+	s.watchers.emitDelete(key, old)
 	return true
 }
 
 // Pop fetching the value for a given key and if the key was found deletes that
 // key from the ConcurrentMap
-func (m ConcurrentMap[K, V]) Pop(key K) (V, bool) {
-	shard := m.getShard(key)
+func (m *ConcurrentMap[K, V]) Pop(key K) (V, bool) {
+	s := m.lazyInit()
+	shard := s.getShard(key)
 	shard.Lock()
 	defer shard.Unlock()
 	val, ok := shard.data[key]
 	if ok {
 		delete(shard.data, key)
+		s.watchers.emitDelete(key, val)
 	}
 	return val, ok
 }
 
+// MDelete deletes multiple keys from the ConcurrentMap and returns the number
+// of keys that were present and deleted.
+//
+// The keys are grouped by shard so that each shard's write lock is acquired only
+// once.
+func (m *ConcurrentMap[K, V]) MDelete(keys ...K) int {
+	s := m.lazyInit()
+	deleted := 0
+	for idx, positions := range s.groupByShard(keys) {
+		shard := s.shards[idx]
+		shard.Lock()
+		for _, pos := range positions {
+			if old, ok := shard.data[keys[pos]]; ok {
+				delete(shard.data, keys[pos])
+				s.watchers.emitDelete(keys[pos], old)
+				deleted++
+			}
+		}
+		shard.Unlock()
+	}
+	return deleted
+}
+
+// DeleteIf deletes all the entries for which the predicate returns true and
+// returns the number of entries deleted.
+//
+// DeleteIf processes one shard at a time holding the write lock for that shard
+// while the predicate is invoked. The predicate must not access the ConcurrentMap
+// or it may deadlock.
+func (m *ConcurrentMap[K, V]) DeleteIf(pred func(key K, val V) bool) int {
+	s := m.lazyInit()
+	deleted := 0
+	for _, shard := range s.shards {
+		shard.Lock()
+		for key, val := range shard.data {
+			if pred(key, val) {
+				delete(shard.data, key)
+				s.watchers.emitDelete(key, val)
+				deleted++
+			}
+		}
+		shard.Unlock()
+	}
+	return deleted
+}
+
+// Clear removes all the entries from the ConcurrentMap.
+//
+// Clear processes one shard at a time, so entries added to shards that were
+// already cleared while Clear is running will remain in the ConcurrentMap.
+func (m *ConcurrentMap[K, V]) Clear() {
+	s := m.lazyInit()
+	for _, shard := range s.shards {
+		shard.Lock()
+		if s.watchers.active() {
+			for key, val := range shard.data {
+				s.watchers.emitDelete(key, val)
+			}
+		}
+		shard.data = make(map[K]V)
+		shard.Unlock()
+	}
+}
+
+// Filter returns a new map containing all the entries for which the predicate
+// returns true.
+//
+// Filter processes one shard at a time holding the read lock for that shard while
+// the predicate is invoked. The predicate must not modify the ConcurrentMap or it
+// may deadlock.
+func (m *ConcurrentMap[K, V]) Filter(pred func(key K, val V) bool) map[K]V {
+	s := m.lazyInit()
+	res := make(map[K]V)
+	for _, shard := range s.shards {
+		shard.RLock()
+		for key, val := range shard.data {
+			if pred(key, val) {
+				res[key] = val
+			}
+		}
+		shard.RUnlock()
+	}
+	return res
+}
+
 // Size returns the approx size (number of elements) in the ConcurrentMap.
 // The size is approximated due to the nature of how the data is sharded.
 // To prevent lock contention each shard is processed and shards already
 // processed may have undergone changes by the time this function returns.
-func (m ConcurrentMap[K, V]) Size() uint64 {
+func (m *ConcurrentMap[K, V]) Size() uint64 {
+	s := m.lazyInit()
 	size := uint64(0)
-	for _, shard := range m.shards {
+	for _, shard := range s.shards {
 		shard.RLock()
 		size = size + uint64(len(shard.data))
 		shard.RUnlock()
@@ -273,10 +455,11 @@ func (m ConcurrentMap[K, V]) Size() uint64 {
 // ConcurrentMap. The returned values represent the size of the shard at the
 // time a read lock was acquired on it. The returned values may not be exact
 // as the shards may have been modified after determining their size.
-func (m ConcurrentMap[K, V]) SizeByShard() map[int]int {
+func (m *ConcurrentMap[K, V]) SizeByShard() map[int]int {
+	s := m.lazyInit()
 	stats := make(map[int]int)
-	for i := range m.shards {
-		shard := m.shards[i]
+	for i := range s.shards {
+		shard := s.shards[i]
 		shard.RLock()
 		stats[i] = len(shard.data)
 		shard.RUnlock()
@@ -288,15 +471,16 @@ func (m ConcurrentMap[K, V]) SizeByShard() map[int]int {
 // across all shards. A read lock is acquired on all shards before retrieving
 // the keys. Keys can be an expensive operation and is not recommended to be
 // called often.
-func (m ConcurrentMap[K, V]) Keys() []K {
+func (m *ConcurrentMap[K, V]) Keys() []K {
+	s := m.lazyInit()
 	keys := make([]K, 0)
-	for i := range m.shards {
-		m.shards[i].RLock()
-		defer m.shards[i].RUnlock()
+	for i := range s.shards {
+		s.shards[i].RLock()
+		defer s.shards[i].RUnlock()
 	}
 
-	for i := range m.shards {
-		shard := m.shards[i]
+	for i := range s.shards {
+		shard := s.shards[i]
 		for key := range shard.data {
 			keys = append(keys, key)
 		}
@@ -310,21 +494,102 @@ func (m ConcurrentMap[K, V]) Keys() []K {
 // lock as it snapshots each shard. This is done for performance reasons as to not
 // hold locks as the caller iterates through the ConcurrentMap. However, this means
 // that the data being iterated through could potentially be stale.
-func (m ConcurrentMap[K, V]) Iterator() *ConcurrentMapIterator[K, V] {
+func (m *ConcurrentMap[K, V]) Iterator() *ConcurrentMapIterator[K, V] {
+	s := m.lazyInit()
 	return &ConcurrentMapIterator[K, V]{
-		data:    m.snapshot(),
+		data:    s.snapshot(),
 		current: -1,
 	}
 }
 
-func (m ConcurrentMap[K, V]) getShard(key K) *mapShard[K, V] {
-	return m.shards[uint(m.hasher(key))%m.shardCount]
+// MarshalJSON marshals a ConcurrentMap into binary JSON representation as a JSON
+// object. The ConcurrentMap is snapshotted shard by shard, so the result may not
+// be consistent across shards if the ConcurrentMap is being modified concurrently.
+func (m ConcurrentMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.snapshotMap())
+}
+
+// UnmarshalJSON unmarshalls binary JSON representation of a ConcurrentMap into
+// this instance of ConcurrentMap. The entries are merged into the existing entries
+// and the shard count and Hasher of the ConcurrentMap are preserved. A zero-value
+// ConcurrentMap is initialized with the defaults before decoding.
+func (m *ConcurrentMap[K, V]) UnmarshalJSON(data []byte) error {
+	var raw map[K]V
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	m.MSet(raw)
+	return nil
+}
+
+// MarshalMsgpack marshals a ConcurrentMap into binary msgpack representation. The
+// ConcurrentMap is snapshotted shard by shard, so the result may not be consistent
+// across shards if the ConcurrentMap is being modified concurrently.
+func (m ConcurrentMap[K, V]) MarshalMsgpack() ([]byte, error) {
+	return msgpack.Marshal(m.snapshotMap())
 }
 
-func (m ConcurrentMap[K, V]) snapshot() []Entry[K, V] {
+// UnmarshalMsgpack unmarshalls binary msgpack representation of a ConcurrentMap
+// into this instance of ConcurrentMap. The entries are merged into the existing
+// entries and the shard count and Hasher of the ConcurrentMap are preserved. A
+// zero-value ConcurrentMap is initialized with the defaults before decoding.
+func (m *ConcurrentMap[K, V]) UnmarshalMsgpack(data []byte) error {
+	var raw map[K]V
+	if err := msgpack.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	m.MSet(raw)
+	return nil
+}
+
+// GobEncode encodes a ConcurrentMap into binary gob representation. The
+// ConcurrentMap is snapshotted shard by shard, so the result may not be consistent
+// across shards if the ConcurrentMap is being modified concurrently.
+func (m ConcurrentMap[K, V]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m.snapshotMap()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes binary gob representation of a ConcurrentMap into this
+// instance of ConcurrentMap. The entries are merged into the existing entries
+// and the shard count and Hasher of the ConcurrentMap are preserved. A zero-value
+// ConcurrentMap is initialized with the defaults before decoding.
+func (m *ConcurrentMap[K, V]) GobDecode(data []byte) error {
+	var raw map[K]V
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&raw); err != nil {
+		return err
+	}
+	m.MSet(raw)
+	return nil
+}
+
+func (s *mapState[K, V]) getShard(key K) *mapShard[K, V] {
+	return s.shards[s.shardIndex(key)]
+}
+
+func (s *mapState[K, V]) shardIndex(key K) uint {
+	return uint(s.hasher(key)) % s.shardCount
+}
+
+// groupByShard groups the positions of the provided keys by the index of the
+// shard they belong to. This allows bulk operations to acquire the lock for each
+// shard only once.
+func (s *mapState[K, V]) groupByShard(keys []K) map[uint][]int {
+	groups := make(map[uint][]int)
+	for i, key := range keys {
+		idx := s.shardIndex(key)
+		groups[idx] = append(groups[idx], i)
+	}
+	return groups
+}
+
+func (s *mapState[K, V]) snapshot() []Entry[K, V] {
 	data := make([]Entry[K, V], 0)
-	for i := range m.shards {
-		shard := m.shards[i]
+	for i := range s.shards {
+		shard := s.shards[i]
 		shard.RLock()
 		for key, val := range shard.data {
 			data = append(data, Entry[K, V]{
@@ -337,6 +602,25 @@ func (m ConcurrentMap[K, V]) snapshot() []Entry[K, V] {
 	return data
 }
 
+// snapshotMap returns a copy of the entries of the ConcurrentMap. An uninitialized
+// zero-value ConcurrentMap is empty.
+func (m *ConcurrentMap[K, V]) snapshotMap() map[K]V {
+	data := make(map[K]V)
+	s := m.load()
+	if s == nil {
+		return data
+	}
+	for i := range s.shards {
+		shard := s.shards[i]
+		shard.RLock()
+		for key, val := range shard.data {
+			data[key] = val
+		}
+		shard.RUnlock()
+	}
+	return data
+}
+
 type ConcurrentMapIterator[K comparable, V any] struct {
 	current int
 	data    []Entry[K, V]
@@ -0,0 +1,271 @@
+package sync
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// SnapshotVersion is the version of the binary snapshot format written by
+// SaveSnapshot.
+const SnapshotVersion uint16 = 1
+
+var (
+	// ErrInvalidSnapshot is a sentinel error value indicating the data being
+	// loaded is not a valid snapshot.
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+	// ErrSnapshotChecksum is a sentinel error value indicating the checksum of the
+	// snapshot doesn't match its contents.
+	ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+)
+
+var snapshotMagic = [4]byte{'C', 'M', 'A', 'P'}
+
+var snapshotTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Codec encodes and decodes values of type T to and from their binary
+// representation.
+type Codec[T any] interface {
+	Marshal(val T) ([]byte, error)
+	Unmarshal(data []byte, val *T) error
+}
+
+// MsgpackCodec is a Codec using msgpack encoding. It is the default Codec used
+// for snapshots.
+type MsgpackCodec[T any] struct{}
+
+// Marshal encodes the value using msgpack.
+func (MsgpackCodec[T]) Marshal(val T) ([]byte, error) {
+	return msgpack.Marshal(val)
+}
+
+// Unmarshal decodes msgpack data into the value.
+func (MsgpackCodec[T]) Unmarshal(data []byte, val *T) error {
+	return msgpack.Unmarshal(data, val)
+}
+
+// JSONCodec is a Codec using JSON encoding.
+type JSONCodec[T any] struct{}
+
+// Marshal encodes the value using JSON.
+func (JSONCodec[T]) Marshal(val T) ([]byte, error) {
+	return json.Marshal(val)
+}
+
+// Unmarshal decodes JSON data into the value.
+func (JSONCodec[T]) Unmarshal(data []byte, val *T) error {
+	return json.Unmarshal(data, val)
+}
+
+// SaveSnapshot writes a binary snapshot of the ConcurrentMap to the provided
+// io.Writer encoding keys and values using msgpack.
+//
+// See SaveSnapshotWithCodec for details of the snapshot format.
+func (m *ConcurrentMap[K, V]) SaveSnapshot(w io.Writer) error {
+	return m.SaveSnapshotWithCodec(w, MsgpackCodec[K]{}, MsgpackCodec[V]{})
+}
+
+// SaveSnapshotWithCodec writes a binary snapshot of the ConcurrentMap to the
+// provided io.Writer encoding keys and values using the provided Codecs.
+//
+// The snapshot starts with a header containing the format version, the shard
+// count and the number of entries. The header is followed by each entry, the key
+// and value each prefixed by their length, and finally a CRC-32 (Castagnoli)
+// checksum of everything that precedes it. Because the checksum can only be known
+// after all the entries have been written it is stored as a trailer, allowing the
+// entries to be streamed to the io.Writer.
+//
+// The ConcurrentMap is snapshotted shard by shard, so the snapshot may not be
+// consistent across shards if the ConcurrentMap is being modified concurrently.
+func (m *ConcurrentMap[K, V]) SaveSnapshotWithCodec(w io.Writer, keyCodec Codec[K], valCodec Codec[V]) error {
+	s := m.lazyInit()
+	entries := s.snapshot()
+
+	bw := bufio.NewWriter(w)
+	checksum := crc32.New(snapshotTable)
+	sw := &snapshotWriter{w: io.MultiWriter(bw, checksum)}
+
+	sw.write(snapshotMagic[:])
+	sw.writeUint16(SnapshotVersion)
+	sw.writeUint32(uint32(s.shardCount))
+	sw.writeUint64(uint64(len(entries)))
+
+	for _, entry := range entries {
+		key, err := keyCodec.Marshal(entry.Key)
+		if err != nil {
+			return fmt.Errorf("encode key: %w", err)
+		}
+		val, err := valCodec.Marshal(entry.Value)
+		if err != nil {
+			return fmt.Errorf("encode value: %w", err)
+		}
+		sw.writeBytes(key)
+		sw.writeBytes(val)
+	}
+	if sw.err != nil {
+		return sw.err
+	}
+
+	if err := binary.Write(bw, binary.BigEndian, checksum.Sum32()); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// LoadSnapshot reads a binary snapshot written by SaveSnapshot from the provided
+// io.Reader and merges its entries into the ConcurrentMap.
+//
+// See LoadSnapshotWithCodec for details.
+func (m *ConcurrentMap[K, V]) LoadSnapshot(r io.Reader) error {
+	return m.LoadSnapshotWithCodec(r, MsgpackCodec[K]{}, MsgpackCodec[V]{})
+}
+
+// LoadSnapshotWithCodec reads a binary snapshot written by SaveSnapshotWithCodec
+// from the provided io.Reader and merges its entries into the ConcurrentMap. The
+// Codecs must match the Codecs that were used to write the snapshot.
+//
+// The shard count and Hasher of the ConcurrentMap are preserved regardless of the
+// shard count recorded in the snapshot. The entries are only applied once the
+// entire snapshot has been read and its checksum verified, so a corrupted or
+// truncated snapshot leaves the ConcurrentMap untouched.
+func (m *ConcurrentMap[K, V]) LoadSnapshotWithCodec(r io.Reader, keyCodec Codec[K], valCodec Codec[V]) error {
+	br := bufio.NewReader(r)
+	checksum := crc32.New(snapshotTable)
+	sr := &snapshotReader{r: io.TeeReader(br, checksum)}
+
+	var magic [4]byte
+	sr.read(magic[:])
+	version := sr.readUint16()
+	_ = sr.readUint32() // shard count of the map the snapshot was taken from
+	count := sr.readUint64()
+	if sr.err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSnapshot, sr.err)
+	}
+	if magic != snapshotMagic {
+		return fmt.Errorf("%w: unrecognized header", ErrInvalidSnapshot)
+	}
+	if version != SnapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, version)
+	}
+
+	data := make(map[K]V)
+	for i := uint64(0); i < count; i++ {
+		keyData := sr.readBytes()
+		valData := sr.readBytes()
+		if sr.err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSnapshot, sr.err)
+		}
+		var key K
+		if err := keyCodec.Unmarshal(keyData, &key); err != nil {
+			return fmt.Errorf("decode key: %w", err)
+		}
+		var val V
+		if err := valCodec.Unmarshal(valData, &val); err != nil {
+			return fmt.Errorf("decode value: %w", err)
+		}
+		data[key] = val
+	}
+
+	var expected uint32
+	if err := binary.Read(br, binary.BigEndian, &expected); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if expected != checksum.Sum32() {
+		return ErrSnapshotChecksum
+	}
+
+	m.MSet(data)
+	return nil
+}
+
+// snapshotWriter writes the primitives of the snapshot format. Once an error
+// occurs all subsequent writes are no-ops and the error is retained.
+type snapshotWriter struct {
+	w   io.Writer
+	buf [8]byte
+	err error
+}
+
+func (sw *snapshotWriter) write(p []byte) {
+	if sw.err != nil {
+		return
+	}
+	_, sw.err = sw.w.Write(p)
+}
+
+func (sw *snapshotWriter) writeUint16(v uint16) {
+	binary.BigEndian.PutUint16(sw.buf[:2], v)
+	sw.write(sw.buf[:2])
+}
+
+func (sw *snapshotWriter) writeUint32(v uint32) {
+	binary.BigEndian.PutUint32(sw.buf[:4], v)
+	sw.write(sw.buf[:4])
+}
+
+func (sw *snapshotWriter) writeUint64(v uint64) {
+	binary.BigEndian.PutUint64(sw.buf[:8], v)
+	sw.write(sw.buf[:8])
+}
+
+func (sw *snapshotWriter) writeBytes(p []byte) {
+	sw.writeUint32(uint32(len(p)))
+	sw.write(p)
+}
+
+// snapshotReader reads the primitives of the snapshot format. Once an error
+// occurs all subsequent reads are no-ops and the error is retained.
+type snapshotReader struct {
+	r   io.Reader
+	buf [8]byte
+	err error
+}
+
+func (sr *snapshotReader) read(p []byte) {
+	if sr.err != nil {
+		return
+	}
+	_, sr.err = io.ReadFull(sr.r, p)
+}
+
+func (sr *snapshotReader) readUint16() uint16 {
+	sr.read(sr.buf[:2])
+	return binary.BigEndian.Uint16(sr.buf[:2])
+}
+
+func (sr *snapshotReader) readUint32() uint32 {
+	sr.read(sr.buf[:4])
+	return binary.BigEndian.Uint32(sr.buf[:4])
+}
+
+func (sr *snapshotReader) readUint64() uint64 {
+	sr.read(sr.buf[:8])
+	return binary.BigEndian.Uint64(sr.buf[:8])
+}
+
+// readBytes reads a length prefixed byte slice. The length hasn't been verified by
+// the checksum yet, so rather than allocating it upfront the buffer grows as the
+// data is read, and a corrupted length fails once the input runs out.
+func (sr *snapshotReader) readBytes() []byte {
+	n := sr.readUint32()
+	if sr.err != nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, sr.r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		sr.err = err
+		return nil
+	}
+	return buf.Bytes()
+}
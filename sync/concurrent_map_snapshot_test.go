@@ -0,0 +1,104 @@
+package sync
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentMap_SaveSnapshot(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	for i := 0; i < 1000; i++ {
+		m.Set(fmt.Sprintf("%d", i), i)
+	}
+
+	var buf bytes.Buffer
+	err := m.SaveSnapshot(&buf)
+	assert.NoError(t, err)
+
+	restored := NewConcurrentMap[string, int](4, StringHasher())
+	restored.Set("existing", -1)
+	err = restored.LoadSnapshot(&buf)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(1001), restored.Size())
+	assert.Equal(t, uint(4), restored.load().shardCount)
+	for i := 0; i < 1000; i++ {
+		val, ok := restored.Get(fmt.Sprintf("%d", i))
+		assert.True(t, ok)
+		assert.Equal(t, i, val)
+	}
+}
+
+func TestConcurrentMap_SaveSnapshotWithCodec(t *testing.T) {
+	type user struct {
+		Name  string
+		Email string
+	}
+
+	m := NewConcurrentMap[int, user](DefaultShards, NewHasher[int]())
+	m.Set(1, user{Name: "Billy", Email: "billy@example.com"})
+	m.Set(2, user{Name: "Jane", Email: "jane@example.com"})
+
+	var buf bytes.Buffer
+	err := m.SaveSnapshotWithCodec(&buf, JSONCodec[int]{}, JSONCodec[user]{})
+	assert.NoError(t, err)
+
+	restored := NewConcurrentMap[int, user](DefaultShards, NewHasher[int]())
+	err = restored.LoadSnapshotWithCodec(&buf, JSONCodec[int]{}, JSONCodec[user]{})
+	assert.NoError(t, err)
+	assert.Equal(t, m.snapshotMap(), restored.snapshotMap())
+}
+
+func TestConcurrentMap_LoadSnapshot_Invalid(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	m.Set("hello", 1)
+	m.Set("world", 2)
+
+	var buf bytes.Buffer
+	assert.NoError(t, m.SaveSnapshot(&buf))
+	snapshot := buf.Bytes()
+
+	t.Run("Bad Header", func(t *testing.T) {
+		restored := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+		err := restored.LoadSnapshot(bytes.NewReader([]byte("not a snapshot at all")))
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		restored := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+		err := restored.LoadSnapshot(bytes.NewReader(snapshot[:len(snapshot)-6]))
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.Equal(t, uint64(0), restored.Size())
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		corrupted := append([]byte(nil), snapshot...)
+		corrupted[len(corrupted)-5]++
+		restored := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+		err := restored.LoadSnapshot(bytes.NewReader(corrupted))
+		assert.ErrorIs(t, err, ErrSnapshotChecksum)
+		assert.Equal(t, uint64(0), restored.Size())
+	})
+
+	t.Run("Huge Length", func(t *testing.T) {
+		// The length of the first key follows the 18 byte header
+		corrupted := append([]byte(nil), snapshot...)
+		binary.BigEndian.PutUint32(corrupted[18:22], math.MaxUint32)
+		restored := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+		err := restored.LoadSnapshot(bytes.NewReader(corrupted))
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.Equal(t, uint64(0), restored.Size())
+	})
+
+	t.Run("Zero Value", func(t *testing.T) {
+		var restored ConcurrentMap[string, int]
+		err := restored.LoadSnapshot(bytes.NewReader(snapshot))
+		assert.NoError(t, err)
+		assert.Equal(t, m.snapshotMap(), restored.snapshotMap())
+	})
+}
@@ -1,17 +1,21 @@
 package sync
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 func TestNewConcurrentMap(t *testing.T) {
 	assert.NotPanics(t, func() {
 		m := NewConcurrentMap[string, int](16, StringHasher())
-		assert.Equal(t, 16, len(m.shards))
-		assert.Equal(t, uint(16), m.shardCount)
+		assert.Equal(t, 16, len(m.load().shards))
+		assert.Equal(t, uint(16), m.load().shardCount)
 	})
 
 	assert.Panics(t, func() {
@@ -25,14 +29,14 @@ func TestConcurrentMap_Get(t *testing.T) {
 		key           string
 		expectedValue string
 		expectedFound bool
-		initFunc      func(m ConcurrentMap[string, string])
+		initFunc      func(m *ConcurrentMap[string, string])
 	}{
 		{
 			name:          "Fetch Existing Key",
 			key:           "hello",
 			expectedValue: "world",
 			expectedFound: true,
-			initFunc: func(m ConcurrentMap[string, string]) {
+			initFunc: func(m *ConcurrentMap[string, string]) {
 				m.Set("hello", "world")
 			},
 		},
@@ -41,7 +45,7 @@ func TestConcurrentMap_Get(t *testing.T) {
 			key:           "test",
 			expectedValue: "",
 			expectedFound: false,
-			initFunc: func(m ConcurrentMap[string, string]) {
+			initFunc: func(m *ConcurrentMap[string, string]) {
 
 			},
 		},
@@ -49,7 +53,7 @@ func TestConcurrentMap_Get(t *testing.T) {
 
 	for _, test := range tests {
 		m := NewConcurrentMap[string, string](DefaultShards, StringHasher())
-		test.initFunc(m)
+		test.initFunc(&m)
 		val, ok := m.Get(test.key)
 		assert.Equal(t, test.expectedValue, val)
 		assert.Equal(t, ok, test.expectedFound)
@@ -72,14 +76,14 @@ func TestConcurrentMap_Pop(t *testing.T) {
 		key           string
 		expectedValue string
 		expectedFound bool
-		init          func(m ConcurrentMap[string, string])
+		init          func(m *ConcurrentMap[string, string])
 	}{
 		{
 			name:          "Value doesn't exist",
 			key:           "hello",
 			expectedValue: "",
 			expectedFound: false,
-			init: func(m ConcurrentMap[string, string]) {
+			init: func(m *ConcurrentMap[string, string]) {
 				// do nothing
 			},
 		},
@@ -88,7 +92,7 @@ func TestConcurrentMap_Pop(t *testing.T) {
 			key:           "hello",
 			expectedValue: "world",
 			expectedFound: true,
-			init: func(m ConcurrentMap[string, string]) {
+			init: func(m *ConcurrentMap[string, string]) {
 				m.Set("hello", "world")
 			},
 		},
@@ -96,7 +100,7 @@ func TestConcurrentMap_Pop(t *testing.T) {
 
 	for _, test := range tests {
 		m := NewConcurrentMap[string, string](DefaultShards, StringHasher())
-		test.init(m)
+		test.init(&m)
 		val, ok := m.Pop(test.key)
 		assert.Equal(t, test.expectedValue, val)
 		assert.Equal(t, test.expectedFound, ok)
@@ -285,3 +289,218 @@ func TestConcurrentMap_Upsert(t *testing.T) {
 		assert.Equal(t, "world!", val)
 	})
 }
+
+func TestConcurrentMap_MGetMap(t *testing.T) {
+	m := NewConcurrentMap[string, string](DefaultShards, StringHasher())
+	m.Set("hello", "world")
+	m.Set("test", "test")
+	m.Set("os", "macOS")
+
+	vals := m.MGetMap("hello", "os", "blah")
+	assert.Equal(t, map[string]string{
+		"hello": "world",
+		"os":    "macOS",
+	}, vals)
+}
+
+func TestConcurrentMap_MDelete(t *testing.T) {
+	m := NewConcurrentMap[string, string](DefaultShards, StringHasher())
+	m.Set("hello", "world")
+	m.Set("test", "test")
+	m.Set("os", "macOS")
+
+	deleted := m.MDelete("hello", "os", "blah")
+	assert.Equal(t, 2, deleted)
+	assert.Equal(t, uint64(1), m.Size())
+	assert.True(t, m.Contains("test"))
+}
+
+func TestConcurrentMap_DeleteIf(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	for i := 0; i < 100; i++ {
+		m.Set(fmt.Sprintf("%d", i), i)
+	}
+
+	deleted := m.DeleteIf(func(key string, val int) bool {
+		return val%2 == 0
+	})
+	assert.Equal(t, 50, deleted)
+	assert.Equal(t, uint64(50), m.Size())
+	assert.False(t, m.Contains("2"))
+	assert.True(t, m.Contains("3"))
+}
+
+func TestConcurrentMap_Clear(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	for i := 0; i < 100; i++ {
+		m.Set(fmt.Sprintf("%d", i), i)
+	}
+
+	m.Clear()
+	assert.Equal(t, uint64(0), m.Size())
+
+	m.Set("hello", 1)
+	assert.Equal(t, uint64(1), m.Size())
+}
+
+func TestConcurrentMap_Filter(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	for i := 0; i < 10; i++ {
+		m.Set(fmt.Sprintf("%d", i), i)
+	}
+
+	res := m.Filter(func(key string, val int) bool {
+		return val > 6
+	})
+	assert.Equal(t, map[string]int{
+		"7": 7,
+		"8": 8,
+		"9": 9,
+	}, res)
+	assert.Equal(t, uint64(10), m.Size())
+}
+
+func TestConcurrentMap_MarshalJSON(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	m.Set("one", 1)
+	m.Set("two", 2)
+
+	data, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"one": 1, "two": 2}`, string(data))
+}
+
+func TestConcurrentMap_UnmarshalJSON(t *testing.T) {
+	m := NewConcurrentMap[string, int](4, StringHasher())
+	m.Set("zero", 0)
+
+	err := json.Unmarshal([]byte(`{"one": 1, "two": 2}`), &m)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), m.Size())
+	assert.Equal(t, uint(4), m.load().shardCount)
+	assert.Equal(t, map[string]int{"zero": 0, "one": 1, "two": 2}, m.snapshotMap())
+
+	var zero ConcurrentMap[string, int]
+	err = json.Unmarshal([]byte(`{"one": 1}`), &zero)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(DefaultShards), zero.load().shardCount)
+	assert.Equal(t, map[string]int{"one": 1}, zero.snapshotMap())
+}
+
+func TestConcurrentMap_Msgpack(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	m.Set("one", 1)
+	m.Set("two", 2)
+
+	data, err := msgpack.Marshal(m)
+	assert.NoError(t, err)
+
+	other := NewConcurrentMap[string, int](8, StringHasher())
+	err = msgpack.Unmarshal(data, &other)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(8), other.load().shardCount)
+	assert.Equal(t, m.snapshotMap(), other.snapshotMap())
+}
+
+func TestConcurrentMap_Gob(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	m.Set("one", 1)
+	m.Set("two", 2)
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(m)
+	assert.NoError(t, err)
+
+	other := NewConcurrentMap[string, int](8, StringHasher())
+	err = gob.NewDecoder(&buf).Decode(&other)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(8), other.load().shardCount)
+	assert.Equal(t, m.snapshotMap(), other.snapshotMap())
+}
+
+func TestConcurrentMap_MarshalByValue(t *testing.T) {
+	type warmCache struct {
+		Name    string
+		Entries ConcurrentMap[string, int]
+	}
+	in := warmCache{Name: "users", Entries: NewConcurrentMap[string, int](4, StringHasher())}
+	in.Entries.Set("one", 1)
+	in.Entries.Set("two", 2)
+
+	data, err := json.Marshal(in)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Name": "users", "Entries": {"one": 1, "two": 2}}`, string(data))
+	var fromJSON warmCache
+	assert.NoError(t, json.Unmarshal(data, &fromJSON))
+	assert.Equal(t, in.Entries.snapshotMap(), fromJSON.Entries.snapshotMap())
+
+	data, err = msgpack.Marshal(in)
+	assert.NoError(t, err)
+	var fromMsgpack warmCache
+	assert.NoError(t, msgpack.Unmarshal(data, &fromMsgpack))
+	assert.Equal(t, in.Entries.snapshotMap(), fromMsgpack.Entries.snapshotMap())
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(in))
+	var fromGob warmCache
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&fromGob))
+	assert.Equal(t, in.Entries.snapshotMap(), fromGob.Entries.snapshotMap())
+
+	// An uninitialized zero-value marshals as an empty map
+	data, err = json.Marshal(warmCache{Name: "empty"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Name": "empty", "Entries": {}}`, string(data))
+}
+
+func TestConcurrentMap_Copy(t *testing.T) {
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	cp := m
+	cp.Set("one", 1)
+
+	// Copies of an initialized ConcurrentMap share the same entries
+	val, ok := m.Get("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+}
+
+func TestConcurrentMap_ZeroValue(t *testing.T) {
+	var m ConcurrentMap[string, int]
+	assert.NotPanics(t, func() {
+		_, ok := m.Get("hello")
+		assert.False(t, ok)
+
+		m.Set("hello", 1)
+		m.Set("world", 2)
+	})
+	assert.Equal(t, uint(DefaultShards), m.load().shardCount)
+	assert.Equal(t, uint64(2), m.Size())
+
+	val, ok := m.Get("hello")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	type holder struct {
+		cache ConcurrentMap[int, string]
+	}
+	h := &holder{}
+	h.cache.Set(1, "one")
+	assert.True(t, h.cache.Contains(1))
+}
+
+func TestMaphashHasher(t *testing.T) {
+	type key struct {
+		ID   int
+		Name string
+	}
+
+	hasher := MaphashHasher[key]()
+	assert.Equal(t, hasher(key{ID: 1, Name: "billy"}), hasher(key{ID: 1, Name: "billy"}))
+
+	m := NewConcurrentMap[key, int](DefaultShards, hasher)
+	m.Set(key{ID: 1, Name: "billy"}, 1)
+	m.Set(key{ID: 2, Name: "jane"}, 2)
+
+	val, ok := m.Get(key{ID: 2, Name: "jane"})
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+}
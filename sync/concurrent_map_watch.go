@@ -0,0 +1,209 @@
+package sync
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// EventType represents the type of mutation that produced an Event.
+type EventType int
+
+const (
+	// EventSet indicates a key was inserted or updated via Set, SetIfPresent,
+	// SetIfAbsent or MSet.
+	EventSet EventType = iota
+	// EventDelete indicates a key was removed from the ConcurrentMap.
+	EventDelete
+	// EventUpsert indicates a key was inserted or updated via Upsert.
+	EventUpsert
+)
+
+// String returns a human-readable representation of the EventType.
+func (t EventType) String() string {
+	switch t {
+	case EventSet:
+		return "Set"
+	case EventDelete:
+		return "Delete"
+	case EventUpsert:
+		return "Upsert"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a notification of a single mutation on a ConcurrentMap.
+//
+// OldValue holds the value of the key prior to the mutation and is only meaningful
+// if Existed is true. NewValue holds the value after the mutation and is the
+// zero-value for EventDelete.
+type Event[K comparable, V any] struct {
+	Type     EventType
+	Key      K
+	OldValue V
+	NewValue V
+	Existed  bool
+}
+
+// WatchFilter is a function type used to filter the events delivered to a watcher.
+// Only events for which all the WatchFilter return true are delivered.
+type WatchFilter[K comparable, V any] func(event Event[K, V]) bool
+
+// KeyPrefix returns a WatchFilter that only accepts events for string keys that
+// start with the given prefix.
+func KeyPrefix[V any](prefix string) WatchFilter[string, V] {
+	return func(event Event[string, V]) bool {
+		return strings.HasPrefix(event.Key, prefix)
+	}
+}
+
+// EventTypes returns a WatchFilter that only accepts events of the given types.
+func EventTypes[K comparable, V any](types ...EventType) WatchFilter[K, V] {
+	return func(event Event[K, V]) bool {
+		for _, t := range types {
+			if event.Type == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Watch subscribes to the mutations on the ConcurrentMap. Every mutation that
+// occurs after Watch returns, and is accepted by all the provided filters, is
+// delivered on the returned channel. The channel is closed once the context is
+// done.
+//
+// Events are published while holding the lock of the shard being mutated, so
+// events for the same key are always delivered in the order they were applied.
+// No ordering guarantees are made across different shards.
+//
+// Watchers never block writers. Events are buffered per watcher until they are
+// received so a consumer that doesn't keep up will cause memory to grow. Filters
+// are invoked while the shard lock is held and must not access the ConcurrentMap.
+func (m *ConcurrentMap[K, V]) Watch(ctx context.Context, filters ...WatchFilter[K, V]) <-chan Event[K, V] {
+	s := m.lazyInit()
+	w := &watcher[K, V]{
+		filters: filters,
+		signal:  make(chan struct{}, 1),
+		events:  make(chan Event[K, V]),
+	}
+	s.watchers.add(w)
+	go func() {
+		defer close(w.events)
+		defer s.watchers.remove(w)
+		w.run(ctx)
+	}()
+	return w.events
+}
+
+// watcher is a single subscriber to a ConcurrentMap's mutations.
+type watcher[K comparable, V any] struct {
+	filters []WatchFilter[K, V]
+	mu      sync.Mutex
+	pending []Event[K, V]
+	signal  chan struct{}
+	events  chan Event[K, V]
+}
+
+func (w *watcher[K, V]) push(event Event[K, V]) {
+	for _, filter := range w.filters {
+		if !filter(event) {
+			return
+		}
+	}
+	w.mu.Lock()
+	w.pending = append(w.pending, event)
+	w.mu.Unlock()
+
+	// Wake up the delivery loop if it isn't already pending a wake-up.
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher[K, V]) run(ctx context.Context) {
+	for {
+		w.mu.Lock()
+		batch := w.pending
+		w.pending = nil
+		w.mu.Unlock()
+
+		if len(batch) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.signal:
+				continue
+			}
+		}
+
+		for _, event := range batch {
+			select {
+			case <-ctx.Done():
+				return
+			case w.events <- event:
+			}
+		}
+	}
+}
+
+// watcherRegistry tracks the active watchers of a ConcurrentMap. It is shared
+// by all copies of a ConcurrentMap.
+type watcherRegistry[K comparable, V any] struct {
+	count    int32
+	mu       sync.RWMutex
+	watchers map[*watcher[K, V]]struct{}
+}
+
+func newWatcherRegistry[K comparable, V any]() *watcherRegistry[K, V] {
+	return &watcherRegistry[K, V]{
+		watchers: make(map[*watcher[K, V]]struct{}),
+	}
+}
+
+func (r *watcherRegistry[K, V]) add(w *watcher[K, V]) {
+	r.mu.Lock()
+	r.watchers[w] = struct{}{}
+	atomic.AddInt32(&r.count, 1)
+	r.mu.Unlock()
+}
+
+func (r *watcherRegistry[K, V]) remove(w *watcher[K, V]) {
+	r.mu.Lock()
+	delete(r.watchers, w)
+	atomic.AddInt32(&r.count, -1)
+	r.mu.Unlock()
+}
+
+// active returns true if there is at least one watcher. It is cheap enough to be
+// called on every mutation.
+func (r *watcherRegistry[K, V]) active() bool {
+	return atomic.LoadInt32(&r.count) > 0
+}
+
+func (r *watcherRegistry[K, V]) emitDelete(key K, oldVal V) {
+	var zero V
+	r.emit(EventDelete, key, oldVal, zero, true)
+}
+
+func (r *watcherRegistry[K, V]) emit(t EventType, key K, oldVal V, newVal V, existed bool) {
+	if !r.active() {
+		return
+	}
+	event := Event[K, V]{
+		Type:     t,
+		Key:      key,
+		OldValue: oldVal,
+		NewValue: newVal,
+		Existed:  existed,
+	}
+	r.mu.RLock()
+	for w := range r.watchers {
+		w.push(event)
+	}
+	r.mu.RUnlock()
+}
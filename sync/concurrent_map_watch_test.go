@@ -0,0 +1,94 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveEvents[K comparable, V any](t *testing.T, events <-chan Event[K, V], n int) []Event[K, V] {
+	t.Helper()
+	received := make([]Event[K, V], 0, n)
+	for len(received) < n {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for events, received %d of %d", len(received), n)
+		}
+	}
+	return received
+}
+
+func TestConcurrentMap_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewConcurrentMap[string, string](DefaultShards, StringHasher())
+	m.Set("existing", "value")
+
+	events := m.Watch(ctx)
+
+	m.Set("hello", "world")
+	m.Set("hello", "world!")
+	m.Upsert("hello", "ignored", func(exist bool, current string, new string) string {
+		return current + "!"
+	})
+	m.Delete("hello")
+	m.Delete("missing")
+
+	assert.Equal(t, []Event[string, string]{
+		{Type: EventSet, Key: "hello", NewValue: "world"},
+		{Type: EventSet, Key: "hello", OldValue: "world", NewValue: "world!", Existed: true},
+		{Type: EventUpsert, Key: "hello", OldValue: "world!", NewValue: "world!!", Existed: true},
+		{Type: EventDelete, Key: "hello", OldValue: "world!!", Existed: true},
+	}, receiveEvents(t, events, 4))
+
+	cancel()
+	for range events {
+		// Drain until the channel is closed
+	}
+	assert.False(t, m.load().watchers.active())
+}
+
+func TestConcurrentMap_WatchFilters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	events := m.Watch(ctx, KeyPrefix[int]("config."), EventTypes[string, int](EventDelete))
+
+	m.Set("config.timeout", 30)
+	m.Set("other", 1)
+	m.Delete("other")
+	m.Delete("config.timeout")
+
+	assert.Equal(t, []Event[string, int]{
+		{Type: EventDelete, Key: "config.timeout", OldValue: 30, Existed: true},
+	}, receiveEvents(t, events, 1))
+
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %v", event)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestConcurrentMap_WatchClear(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	m.Set("one", 1)
+	m.Set("two", 2)
+
+	events := m.Watch(ctx)
+	m.Clear()
+
+	assert.ElementsMatch(t, []Event[string, int]{
+		{Type: EventDelete, Key: "one", OldValue: 1, Existed: true},
+		{Type: EventDelete, Key: "two", OldValue: 2, Existed: true},
+	}, receiveEvents(t, events, 2))
+}
@@ -0,0 +1,160 @@
+package sync
+
+import (
+	"sync"
+
+	collections "github.com/jkratz55/collections-go"
+)
+
+// ConcurrentOrderedMap is a thread safe wrapper around collections.OrderedMap
+// which maintains the order keys were inserted into the map. All operations are
+// protected by a RWMutex, allowing concurrent reads.
+//
+// Iteration via ForEach and ForEachReverse operates on a snapshot of the entries
+// taken while holding the read lock. The lock is released before the provided
+// function is invoked, so the function may safely access the ConcurrentOrderedMap.
+//
+// The zero-value of ConcurrentOrderedMap is not usable. NewConcurrentOrderedMap
+// should be used to create and initialize a new instance of ConcurrentOrderedMap.
+type ConcurrentOrderedMap[K comparable, V any] struct {
+	data *collections.OrderedMap[K, V]
+	mu   sync.RWMutex
+}
+
+// NewConcurrentOrderedMap creates and initializes a new ConcurrentOrderedMap
+func NewConcurrentOrderedMap[K comparable, V any]() *ConcurrentOrderedMap[K, V] {
+	return &ConcurrentOrderedMap[K, V]{
+		data: collections.NewOrderedMap[K, V](),
+	}
+}
+
+// Set inserts a new key/value into the map or replaces the value for an existing
+// key. If the key didn't exist in the map and was inserted true is returned.
+// Otherwise, if they key was already existing returns false.
+func (m *ConcurrentOrderedMap[K, V]) Set(key K, val V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data.Set(key, val)
+}
+
+// Contains returns true if the given key exists in the ConcurrentOrderedMap,
+// otherwise returns false.
+func (m *ConcurrentOrderedMap[K, V]) Contains(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.data.Contains(key)
+}
+
+// Get retrieves the value for a key. It follows the same idioms of the built-in
+// map. If the key doesn't exist the zero value and false value are returned.
+func (m *ConcurrentOrderedMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.data.Get(key)
+}
+
+// GetOrDefault retrieves the value for a key and if it doesn't exist returns the
+// provided default value.
+func (m *ConcurrentOrderedMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.data.GetOrDefault(key, defaultValue)
+}
+
+// GetOrSet atomically retrieves the value for a key, or if the key doesn't exist
+// inserts the provided value. The value now associated with the key is returned
+// along with a boolean which is true if the value was loaded, false if it was
+// inserted.
+func (m *ConcurrentOrderedMap[K, V]) GetOrSet(key K, val V) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.data.Get(key); ok {
+		return existing, true
+	}
+	m.data.Set(key, val)
+	return val, false
+}
+
+// ComputeFunc is a function type that is invoked by the Compute method. It accepts
+// the current value for a key (or zero-value if it doesn't exist) and a boolean
+// indicating if the key exists. It returns the new value for the key and a boolean
+// indicating if the key should be kept. If false is returned the key is deleted.
+type ComputeFunc[V any] func(current V, exists bool) (V, bool)
+
+// Compute atomically computes a new value for a key using the provided ComputeFunc.
+// If the ComputeFunc returns true the returned value is set for the key, otherwise
+// the key is deleted. Compute returns the value now associated with the key and a
+// boolean indicating if the key exists.
+//
+// A key that didn't exist is inserted at the end of the map, updating an existing
+// key retains its position.
+//
+// Important: If the ComputeFunc tries to access the ConcurrentOrderedMap it will
+// deadlock because locks in Go are not reentrant.
+func (m *ConcurrentOrderedMap[K, V]) Compute(key K, fn ComputeFunc[V]) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, exists := m.data.Get(key)
+	val, keep := fn(current, exists)
+	if !keep {
+		m.data.Delete(key)
+		var zero V
+		return zero, false
+	}
+	m.data.Set(key, val)
+	return val, true
+}
+
+// Delete removes a key/value entry from the ConcurrentOrderedMap. If the key
+// didn't exist returns false so, otherwise returns true if the entry was deleted.
+func (m *ConcurrentOrderedMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data.Delete(key)
+}
+
+// Size returns the number of entries in the ConcurrentOrderedMap
+func (m *ConcurrentOrderedMap[K, V]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.data.Size()
+}
+
+// Keys returns the keys in the order they were inserted.
+func (m *ConcurrentOrderedMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.data.Keys()
+}
+
+// Entries returns a snapshot of the entries in the order they were inserted.
+func (m *ConcurrentOrderedMap[K, V]) Entries() []Entry[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entries := make([]Entry[K, V], 0, m.data.Size())
+	m.data.ForEach(func(key K, val V) {
+		entries = append(entries, Entry[K, V]{
+			Key:   key,
+			Value: val,
+		})
+	})
+	return entries
+}
+
+// ForEach iterates through a snapshot of the map entries passing the key/value
+// pair to the provided function/closure in the order they were inserted.
+func (m *ConcurrentOrderedMap[K, V]) ForEach(fn func(key K, val V)) {
+	for _, entry := range m.Entries() {
+		fn(entry.Key, entry.Value)
+	}
+}
+
+// ForEachReverse iterates through a snapshot of the map entries passing the
+// key/value pairs to the provided function/closure in the reverse order they were
+// inserted (most recently inserted to least recently)
+func (m *ConcurrentOrderedMap[K, V]) ForEachReverse(fn func(key K, val V)) {
+	entries := m.Entries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		fn(entries[i].Key, entries[i].Value)
+	}
+}
@@ -0,0 +1,97 @@
+package sync
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentOrderedMap(t *testing.T) {
+	m := NewConcurrentOrderedMap[string, string]()
+	assert.True(t, m.Set("Hello", "World"))
+	assert.True(t, m.Set("Billy", "Bob"))
+	assert.True(t, m.Set("John", "Doe"))
+	assert.False(t, m.Set("John", "Smith"))
+
+	val, ok := m.Get("John")
+	assert.True(t, ok)
+	assert.Equal(t, "Smith", val)
+	assert.Equal(t, "default", m.GetOrDefault("Jane", "default"))
+	assert.True(t, m.Contains("Billy"))
+	assert.Equal(t, 3, m.Size())
+
+	assert.True(t, m.Delete("Billy"))
+	assert.False(t, m.Delete("Billy"))
+	assert.Equal(t, []string{"Hello", "John"}, m.Keys())
+	assert.Equal(t, []Entry[string, string]{
+		{Key: "Hello", Value: "World"},
+		{Key: "John", Value: "Smith"},
+	}, m.Entries())
+}
+
+func TestConcurrentOrderedMap_ForEach(t *testing.T) {
+	m := NewConcurrentOrderedMap[string, int]()
+	m.Set("one", 1)
+	m.Set("two", 2)
+	m.Set("three", 3)
+
+	var keys []string
+	m.ForEach(func(key string, val int) {
+		// Accessing the map from the callback must not deadlock
+		m.Set(key, val*10)
+		keys = append(keys, key)
+	})
+	assert.Equal(t, []string{"one", "two", "three"}, keys)
+	assert.Equal(t, 30, m.GetOrDefault("three", 0))
+
+	keys = nil
+	m.ForEachReverse(func(key string, val int) {
+		keys = append(keys, key)
+	})
+	assert.Equal(t, []string{"three", "two", "one"}, keys)
+}
+
+func TestConcurrentOrderedMap_GetOrSet(t *testing.T) {
+	m := NewConcurrentOrderedMap[string, int]()
+
+	val, loaded := m.GetOrSet("one", 1)
+	assert.False(t, loaded)
+	assert.Equal(t, 1, val)
+
+	val, loaded = m.GetOrSet("one", 100)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, val)
+}
+
+func TestConcurrentOrderedMap_Compute(t *testing.T) {
+	m := NewConcurrentOrderedMap[string, int]()
+	m.Set("first", 0)
+
+	increment := func(current int, exists bool) (int, bool) {
+		return current + 1, true
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Compute(fmt.Sprintf("%d", i%2), increment)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, "first", m.Keys()[0])
+	assert.Equal(t, 50, m.GetOrDefault("0", 0))
+	assert.Equal(t, 50, m.GetOrDefault("1", 0))
+
+	val, ok := m.Compute("first", func(current int, exists bool) (int, bool) {
+		assert.True(t, exists)
+		return 0, false
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 0, val)
+	assert.False(t, m.Contains("first"))
+}
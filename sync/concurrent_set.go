@@ -0,0 +1,156 @@
+package sync
+
+import (
+	"encoding/json"
+
+	collections "github.com/jkratz55/collections-go"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ConcurrentSet is a thread safe collection that contains no duplicate elements.
+// ConcurrentSet is backed by a ConcurrentMap and shards its elements to reduce
+// lock contention.
+//
+// ConcurrentSet makes no guarantees as to the iteration order of the set. Set
+// algebra operations such as Union and Intersection return a non thread safe
+// collections.Set which is a snapshot of the result.
+//
+// The zero-value of ConcurrentSet is an empty set ready to use, see ConcurrentMap
+// for the defaults used. A ConcurrentSet must not be copied after first use.
+//
+// ConcurrentSet supports marshaling/unmarshalling for json and msgpack using the
+// same representation as collections.Set.
+type ConcurrentSet[T comparable] struct {
+	data ConcurrentMap[T, struct{}]
+}
+
+// NewConcurrentSet creates and initializes a new empty ConcurrentSet. The shards
+// and hasher parameters follow the same semantics as NewConcurrentMap.
+func NewConcurrentSet[T comparable](shards int, hasher Hasher[T]) *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{
+		data: NewConcurrentMap[T, struct{}](shards, hasher),
+	}
+}
+
+// Add adds the elements into the ConcurrentSet. If an element already exists it
+// is effectively a no-op.
+func (s *ConcurrentSet[T]) Add(vals ...T) {
+	for _, val := range vals {
+		s.data.Set(val, struct{}{})
+	}
+}
+
+// AddIfAbsent adds the element into the ConcurrentSet if it doesn't already exist
+// and returns true if the element was newly added.
+func (s *ConcurrentSet[T]) AddIfAbsent(val T) bool {
+	return s.data.SetIfAbsent(val, struct{}{})
+}
+
+// Remove removes/deletes an element from the ConcurrentSet returning a boolean
+// indicating if the element was present.
+func (s *ConcurrentSet[T]) Remove(val T) bool {
+	return s.data.Delete(val)
+}
+
+// Contains returns a boolean value indicating if the provided value is in the
+// ConcurrentSet.
+func (s *ConcurrentSet[T]) Contains(val T) bool {
+	return s.data.Contains(val)
+}
+
+// Size returns the approx number of elements in the ConcurrentSet. See
+// ConcurrentMap.Size for why the size is approximated.
+func (s *ConcurrentSet[T]) Size() int {
+	return int(s.data.Size())
+}
+
+// Clear removes all the elements from the ConcurrentSet.
+func (s *ConcurrentSet[T]) Clear() {
+	s.data.Clear()
+}
+
+// ForEach iterates through a snapshot of the ConcurrentSet passing each value to
+// the provided function. Since a snapshot is iterated the function may safely
+// access the ConcurrentSet.
+func (s *ConcurrentSet[T]) ForEach(fn func(val T)) {
+	for _, val := range s.AsSlice() {
+		fn(val)
+	}
+}
+
+// AsSlice returns the elements of the ConcurrentSet as a built-in Go slice.
+func (s *ConcurrentSet[T]) AsSlice() []T {
+	return s.data.Keys()
+}
+
+// Snapshot returns a non thread safe collections.Set containing the elements of
+// the ConcurrentSet.
+func (s *ConcurrentSet[T]) Snapshot() *collections.Set[T] {
+	set := collections.NewSet[T]()
+	set.Add(s.AsSlice()...)
+	return set
+}
+
+// Union returns a new collections.Set which contains all the elements in both
+// sets.
+func (s *ConcurrentSet[T]) Union(other *ConcurrentSet[T]) *collections.Set[T] {
+	set := s.Snapshot()
+	set.Add(other.AsSlice()...)
+	return set
+}
+
+// Intersection returns a new collections.Set which contains the elements that
+// exist in both sets.
+func (s *ConcurrentSet[T]) Intersection(other *ConcurrentSet[T]) *collections.Set[T] {
+	set := collections.NewSet[T]()
+	for _, val := range s.AsSlice() {
+		if other.Contains(val) {
+			set.Add(val)
+		}
+	}
+	return set
+}
+
+// Difference returns a new collections.Set which contains the elements of this
+// set that don't exist in the other set.
+func (s *ConcurrentSet[T]) Difference(other *ConcurrentSet[T]) *collections.Set[T] {
+	set := collections.NewSet[T]()
+	for _, val := range s.AsSlice() {
+		if !other.Contains(val) {
+			set.Add(val)
+		}
+	}
+	return set
+}
+
+// MarshalJSON marshals a ConcurrentSet into binary JSON representation
+func (s *ConcurrentSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.AsSlice())
+}
+
+// UnmarshalJSON unmarshalls binary JSON representation of a ConcurrentSet into
+// this instance of ConcurrentSet.
+func (s *ConcurrentSet[T]) UnmarshalJSON(data []byte) error {
+	var raw []T
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Add(raw...)
+	return nil
+}
+
+// MarshalMsgpack marshals a ConcurrentSet into binary msgpack representation.
+func (s *ConcurrentSet[T]) MarshalMsgpack() ([]byte, error) {
+	return msgpack.Marshal(s.AsSlice())
+}
+
+// UnmarshalMsgpack unmarshalls binary msgpack representation of a ConcurrentSet
+// into this instance of ConcurrentSet.
+func (s *ConcurrentSet[T]) UnmarshalMsgpack(data []byte) error {
+	var raw []T
+	if err := msgpack.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Add(raw...)
+	return nil
+}
@@ -0,0 +1,98 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestConcurrentSet(t *testing.T) {
+	s := NewConcurrentSet[string](DefaultShards, StringHasher())
+	s.Add("pizza", "tacos", "hamburger")
+
+	assert.Equal(t, 3, s.Size())
+	assert.True(t, s.Contains("pizza"))
+	assert.False(t, s.Contains("pasta"))
+
+	assert.True(t, s.AddIfAbsent("pasta"))
+	assert.False(t, s.AddIfAbsent("pasta"))
+
+	assert.True(t, s.Remove("pizza"))
+	assert.False(t, s.Remove("pizza"))
+	assert.ElementsMatch(t, []string{"tacos", "hamburger", "pasta"}, s.AsSlice())
+
+	s.Clear()
+	assert.Equal(t, 0, s.Size())
+}
+
+func TestConcurrentSet_ZeroValue(t *testing.T) {
+	var s ConcurrentSet[int]
+	assert.True(t, s.AddIfAbsent(1))
+	assert.True(t, s.Contains(1))
+}
+
+func TestConcurrentSet_AddIfAbsent_Concurrent(t *testing.T) {
+	s := NewConcurrentSet[string](DefaultShards, StringHasher())
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	added := 0
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if s.AddIfAbsent(fmt.Sprintf("%d", i%10)) {
+				mu.Lock()
+				added++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 10, added)
+	assert.Equal(t, 10, s.Size())
+}
+
+func TestConcurrentSet_SetAlgebra(t *testing.T) {
+	s1 := NewConcurrentSet[string](DefaultShards, StringHasher())
+	s1.Add("pizza", "tacos", "hamburger")
+
+	s2 := NewConcurrentSet[string](DefaultShards, StringHasher())
+	s2.Add("pizza", "pasta")
+
+	assert.ElementsMatch(t, []string{"pizza", "tacos", "hamburger"}, s1.Snapshot().AsSlice())
+	assert.ElementsMatch(t, []string{"pizza", "tacos", "hamburger", "pasta"}, s1.Union(s2).AsSlice())
+	assert.ElementsMatch(t, []string{"pizza"}, s1.Intersection(s2).AsSlice())
+	assert.ElementsMatch(t, []string{"tacos", "hamburger"}, s1.Difference(s2).AsSlice())
+	assert.ElementsMatch(t, []string{"pasta"}, s2.Difference(s1).AsSlice())
+}
+
+func TestConcurrentSet_JSON(t *testing.T) {
+	s := NewConcurrentSet[string](DefaultShards, StringHasher())
+	s.Add("pizza", "tacos", "hamburger")
+
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+
+	other := NewConcurrentSet[string](DefaultShards, StringHasher())
+	err = json.Unmarshal(data, other)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"pizza", "tacos", "hamburger"}, other.AsSlice())
+}
+
+func TestConcurrentSet_Msgpack(t *testing.T) {
+	s := NewConcurrentSet[string](DefaultShards, StringHasher())
+	s.Add("pizza", "tacos", "hamburger")
+
+	data, err := msgpack.Marshal(s)
+	assert.NoError(t, err)
+
+	other := NewConcurrentSet[string](DefaultShards, StringHasher())
+	err = msgpack.Unmarshal(data, other)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"pizza", "tacos", "hamburger"}, other.AsSlice())
+}
@@ -0,0 +1,134 @@
+package sync
+
+import (
+	"sync"
+)
+
+// COWMap is a thread safe copy-on-write map optimized for read heavy workloads
+// where writes are rare, such as configuration or routing tables.
+//
+// COWMap holds an immutable map behind an atomic reference. Reads are lock-free
+// and never contend with each other or with writers. Every write copies the
+// current map, applies the change to the copy and atomically swaps it in. This
+// makes writes O(n), so multiple changes should be batched using Update.
+//
+// On Go 1.19 and later the map is held by an atomic.Pointer so reads don't pay for
+// the type assertion of an atomic.Value, which is used on earlier versions.
+//
+// The zero-value of COWMap is an empty map ready to use. A COWMap must not be
+// copied after first use.
+type COWMap[K comparable, V any] struct {
+	// data holds the current map[K]V which must never be modified once stored.
+	data cowRef[K, V]
+	// mu serializes writers so concurrent writes aren't lost.
+	mu sync.Mutex
+}
+
+// NewCOWMap creates and initializes a new COWMap containing a copy of the
+// provided entries. A nil map results in an empty COWMap.
+func NewCOWMap[K comparable, V any](entries map[K]V) *COWMap[K, V] {
+	m := &COWMap[K, V]{}
+	m.data.Store(cloneMap(entries))
+	return m
+}
+
+// Get retrieves the value for a key. Get follows the same semantics of the
+// built-in map returning the value and a boolean indicating if the key exists.
+func (m *COWMap[K, V]) Get(key K) (V, bool) {
+	val, ok := m.load()[key]
+	return val, ok
+}
+
+// Contains returns a boolean indicating if the key exists.
+func (m *COWMap[K, V]) Contains(key K) bool {
+	_, ok := m.load()[key]
+	return ok
+}
+
+// Size returns the number of entries in the COWMap.
+func (m *COWMap[K, V]) Size() int {
+	return len(m.load())
+}
+
+// Keys returns all the keys in the COWMap.
+func (m *COWMap[K, V]) Keys() []K {
+	data := m.load()
+	keys := make([]K, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// ForEach iterates through the entries of the COWMap passing the key/value pairs
+// to the provided function. ForEach iterates over the map as it was when ForEach
+// was invoked, writes made during iteration are not observed. The function may
+// safely read or write to the COWMap.
+func (m *COWMap[K, V]) ForEach(fn func(key K, val V)) {
+	for key, val := range m.load() {
+		fn(key, val)
+	}
+}
+
+// Snapshot returns a copy of the entries in the COWMap.
+func (m *COWMap[K, V]) Snapshot() map[K]V {
+	return cloneMap(m.load())
+}
+
+// Set inserts or updates the value for a key.
+func (m *COWMap[K, V]) Set(key K, val V) {
+	m.Update(func(data map[K]V) {
+		data[key] = val
+	})
+}
+
+// Delete deletes a key from the COWMap returning a boolean indicating if the key
+// was present or not.
+func (m *COWMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := m.load()
+	if _, ok := current[key]; !ok {
+		return false
+	}
+	data := cloneMap(current)
+	delete(data, key)
+	m.data.Store(data)
+	return true
+}
+
+// Update copies the current map and passes the copy to the provided function to
+// be modified. Once the function returns the modified copy atomically replaces
+// the current map. This allows many changes to be applied for the cost of a
+// single copy, and readers never observe a partially applied Update.
+//
+// Writers are serialized, so the function must not write to the COWMap or it
+// will deadlock. The function must not retain the map after it returns.
+func (m *COWMap[K, V]) Update(fn func(data map[K]V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := cloneMap(m.load())
+	fn(data)
+	m.data.Store(data)
+}
+
+// Clear removes all the entries from the COWMap.
+func (m *COWMap[K, V]) Clear() {
+	m.mu.Lock()
+	m.data.Store(make(map[K]V))
+	m.mu.Unlock()
+}
+
+func (m *COWMap[K, V]) load() map[K]V {
+	return m.data.Load()
+}
+
+func cloneMap[K comparable, V any](src map[K]V) map[K]V {
+	dst := make(map[K]V, len(src))
+	for key, val := range src {
+		dst[key] = val
+	}
+	return dst
+}
@@ -0,0 +1,83 @@
+package sync
+
+import (
+	"fmt"
+	"testing"
+)
+
+const benchmarkReadKeys = 1000
+
+func benchmarkKeys() []string {
+	keys := make([]string, benchmarkReadKeys)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%d", i)
+	}
+	return keys
+}
+
+func BenchmarkCOWMapParallelReads(b *testing.B) {
+	keys := benchmarkKeys()
+	m := NewCOWMap[string, int](nil)
+	m.Update(func(data map[string]int) {
+		for i, key := range keys {
+			data[key] = i
+		}
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = m.Get(keys[i%benchmarkReadKeys])
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentMapParallelReads(b *testing.B) {
+	keys := benchmarkKeys()
+	m := NewConcurrentMap[string, int](DefaultShards, StringHasher())
+	for i, key := range keys {
+		m.Set(key, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = m.Get(keys[i%benchmarkReadKeys])
+			i++
+		}
+	})
+}
+
+func BenchmarkMapParallelReads(b *testing.B) {
+	keys := benchmarkKeys()
+	m := Map[string, int]{}
+	for i, key := range keys {
+		m.Store(key, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = m.Load(keys[i%benchmarkReadKeys])
+			i++
+		}
+	})
+}
+
+func BenchmarkCOWMapWrites(b *testing.B) {
+	keys := benchmarkKeys()
+	m := NewCOWMap[string, int](nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Set(keys[i%benchmarkReadKeys], i)
+	}
+}
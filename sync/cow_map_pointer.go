@@ -0,0 +1,24 @@
+//go:build go1.19
+
+package sync
+
+import (
+	"sync/atomic"
+)
+
+// cowRef is an atomic reference to the current map of a COWMap. The zero-value
+// holds a nil map.
+type cowRef[K comparable, V any] struct {
+	p atomic.Pointer[map[K]V]
+}
+
+func (r *cowRef[K, V]) Load() map[K]V {
+	if p := r.p.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+func (r *cowRef[K, V]) Store(data map[K]V) {
+	r.p.Store(&data)
+}
@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCOWMap(t *testing.T) {
+	entries := map[string]int{"one": 1, "two": 2}
+	m := NewCOWMap(entries)
+
+	entries["three"] = 3
+	assert.Equal(t, 2, m.Size())
+	assert.False(t, m.Contains("three"))
+}
+
+func TestCOWMap_ZeroValue(t *testing.T) {
+	var m COWMap[string, int]
+
+	val, ok := m.Get("hello")
+	assert.False(t, ok)
+	assert.Equal(t, 0, val)
+	assert.Equal(t, 0, m.Size())
+
+	m.Set("hello", 1)
+	val, ok = m.Get("hello")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+}
+
+func TestCOWMap_SetDelete(t *testing.T) {
+	m := NewCOWMap[string, string](nil)
+	m.Set("hello", "world")
+	m.Set("test", "test")
+
+	assert.True(t, m.Contains("hello"))
+	assert.ElementsMatch(t, []string{"hello", "test"}, m.Keys())
+
+	assert.True(t, m.Delete("hello"))
+	assert.False(t, m.Delete("hello"))
+	assert.Equal(t, map[string]string{"test": "test"}, m.Snapshot())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+}
+
+func TestCOWMap_Update(t *testing.T) {
+	m := NewCOWMap(map[string]int{"one": 1})
+	before := m.Snapshot()
+
+	m.Update(func(data map[string]int) {
+		delete(data, "one")
+		data["two"] = 2
+		data["three"] = 3
+	})
+
+	assert.Equal(t, map[string]int{"one": 1}, before)
+	assert.Equal(t, map[string]int{"two": 2, "three": 3}, m.Snapshot())
+}
+
+func TestCOWMap_ForEach(t *testing.T) {
+	m := NewCOWMap(map[string]int{"one": 1, "two": 2})
+
+	actual := make(map[string]int)
+	m.ForEach(func(key string, val int) {
+		// Writes during iteration are not observed by ForEach
+		m.Set(key+"!", val)
+		actual[key] = val
+	})
+	assert.Equal(t, map[string]int{"one": 1, "two": 2}, actual)
+	assert.Equal(t, 4, m.Size())
+}
+
+func TestCOWMap_ConcurrentWrites(t *testing.T) {
+	var m COWMap[string, int]
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Set(fmt.Sprintf("%d", i), i)
+			_, _ = m.Get(fmt.Sprintf("%d", i-1))
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 100, m.Size())
+}
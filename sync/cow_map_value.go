@@ -0,0 +1,23 @@
+//go:build !go1.19
+
+package sync
+
+import (
+	"sync/atomic"
+)
+
+// cowRef is an atomic reference to the current map of a COWMap. atomic.Pointer
+// isn't available before Go 1.19 so the map is held by an atomic.Value. The
+// zero-value holds a nil map.
+type cowRef[K comparable, V any] struct {
+	v atomic.Value
+}
+
+func (r *cowRef[K, V]) Load() map[K]V {
+	data, _ := r.v.Load().(map[K]V)
+	return data
+}
+
+func (r *cowRef[K, V]) Store(data map[K]V) {
+	r.v.Store(data)
+}
@@ -0,0 +1,82 @@
+package sync
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// comparableHasher returns a Hasher for any comparable key type which hashes the
+// key by walking its value with reflection. Keys that are equal according to ==
+// always produce the same hash: floating point zeros are normalized so -0 and +0
+// hash the same, and pointers and channels are hashed by address rather than by
+// what they point to. Interfaces, and structs containing them, don't satisfy
+// comparable before Go 1.20 so they are never hashed.
+//
+// comparableHasher backs MaphashHasher prior to Go 1.24, where hash/maphash can't
+// hash arbitrary comparable values.
+func comparableHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return func(key K) uint32 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		if str, ok := any(key).(string); ok {
+			_, _ = h.WriteString(str)
+		} else {
+			writeHashValue(&h, reflect.ValueOf(key))
+		}
+		return uint32(h.Sum64())
+	}
+}
+
+func writeHashValue(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeHashUint64(h, 1)
+		} else {
+			writeHashUint64(h, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeHashUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeHashUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeHashFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeHashFloat(h, real(c))
+		writeHashFloat(h, imag(c))
+	case reflect.String:
+		writeHashUint64(h, uint64(v.Len()))
+		_, _ = h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeHashUint64(h, uint64(v.Pointer()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeHashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writeHashValue(h, v.Field(i))
+		}
+	default:
+		panic(fmt.Sprintf("cannot hash key of non-comparable type %s", v.Type()))
+	}
+}
+
+func writeHashFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		// Normalize -0 to +0 since they are equal
+		f = 0
+	}
+	writeHashUint64(h, math.Float64bits(f))
+}
+
+func writeHashUint64(h *maphash.Hash, v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	_, _ = h.Write(buf[:])
+}
@@ -0,0 +1,60 @@
+package sync
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparableHasher(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+
+	floats := comparableHasher[float64]()
+	assert.Equal(t, floats(0), floats(negZero))
+	assert.NotEqual(t, floats(1), floats(2))
+
+	complexes := comparableHasher[complex128]()
+	assert.Equal(t, complexes(complex(0, 0)), complexes(complex(negZero, negZero)))
+
+	type point struct {
+		X, Y float64
+		Name string
+	}
+	points := comparableHasher[point]()
+	assert.Equal(t, points(point{X: 0, Y: 1, Name: "a"}), points(point{X: negZero, Y: 1, Name: "a"}))
+
+	arrays := comparableHasher[[2]float32]()
+	assert.Equal(t, arrays([2]float32{0, 1}), arrays([2]float32{float32(negZero), 1}))
+
+	// Pointers are hashed by address, not by what they point to
+	pointers := comparableHasher[*point]()
+	p := &point{Name: "a"}
+	before := pointers(p)
+	p.Name = "b"
+	assert.Equal(t, before, pointers(p))
+
+	strs := comparableHasher[string]()
+	assert.Equal(t, strs("hello"), strs("hello"))
+}
+
+func TestComparableHasher_ConcurrentMap(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+
+	m := NewConcurrentMap[float64, string](DefaultShards, comparableHasher[float64]())
+	m.Set(0, "zero")
+	m.Set(negZero, "negative zero")
+	assert.Equal(t, uint64(1), m.Size())
+	val, ok := m.Get(0)
+	assert.True(t, ok)
+	assert.Equal(t, "negative zero", val)
+
+	type node struct {
+		Name string
+	}
+	pointers := NewConcurrentMap[*node, int](DefaultShards, comparableHasher[*node]())
+	n := &node{Name: "a"}
+	pointers.Set(n, 1)
+	n.Name = "b"
+	assert.True(t, pointers.Contains(n))
+}
@@ -0,0 +1,19 @@
+//go:build go1.24
+
+package sync
+
+import (
+	"hash/maphash"
+)
+
+// MaphashHasher returns a Hasher for any comparable key type backed by the
+// hash/maphash package. Each Hasher returned uses its own random seed, so the
+// hashes are only stable for the lifetime of the Hasher.
+//
+// MaphashHasher is the Hasher used by a zero-value ConcurrentMap.
+func MaphashHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return func(key K) uint32 {
+		return uint32(maphash.Comparable(seed, key))
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !go1.24
+
+package sync
+
+// MaphashHasher returns a Hasher for any comparable key type backed by the
+// hash/maphash package. Each Hasher returned uses its own random seed, so the
+// hashes are only stable for the lifetime of the Hasher.
+//
+// MaphashHasher is the Hasher used by a zero-value ConcurrentMap.
+//
+// Prior to Go 1.24 the hash/maphash package can't hash arbitrary comparable values
+// so the key is walked using reflection instead, which is slower. Keys that are
+// equal always hash the same, including -0 and +0 floating point keys, and pointer
+// keys are hashed by address.
+func MaphashHasher[K comparable]() Hasher[K] {
+	return comparableHasher[K]()
+}
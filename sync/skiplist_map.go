@@ -0,0 +1,297 @@
+package sync
+
+import (
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	collections "github.com/jkratz55/collections-go"
+)
+
+// skipListMaxLevel is the maximum number of levels of a SkipListMap, which is
+// enough for well over a billion entries.
+const skipListMaxLevel = 32
+
+// SkipListMap is a thread safe map which keeps its entries sorted by key. Unlike
+// ConcurrentMap, which shards by hash, SkipListMap can answer ordered queries
+// such as Range, making it suitable as a concurrent ordered index.
+//
+// SkipListMap is a lazy skip list. Get, Contains, Range and ForEach are lock-free
+// and never block. Set and Delete only lock the few nodes adjacent to the key
+// being modified, so writers to different parts of the map don't contend. Get,
+// Set and Delete are O(log n) operations on average.
+//
+// Iteration via Range and ForEach is weakly consistent, it reflects some of the
+// modifications made concurrently with the iteration and never returns an entry
+// more than once. The provided function may safely access the SkipListMap.
+//
+// The zero-value of SkipListMap is not usable. NewSkipListMap or
+// NewSkipListMapFunc should be used to create and initialize a new instance of
+// SkipListMap.
+type SkipListMap[K any, V any] struct {
+	// size and seed are accessed atomically and are kept first so they are 64-bit
+	// aligned on 32-bit platforms.
+	size int64
+	seed uint64
+	head *skipListNode[K, V]
+	cmp  func(a, b K) int
+}
+
+type skipListNode[K any, V any] struct {
+	key K
+	// val holds a *V so values of different concrete types can be stored when V
+	// is an interface type.
+	val  atomic.Value
+	next []atomic.Value
+	// mu guards linking and unlinking the node.
+	mu          sync.Mutex
+	marked      uint32
+	fullyLinked uint32
+}
+
+// NewSkipListMap creates and initializes a new SkipListMap ordering keys by their
+// natural order.
+func NewSkipListMap[K collections.Ordered, V any]() *SkipListMap[K, V] {
+	return NewSkipListMapFunc[K, V](collections.Compare[K])
+}
+
+// NewSkipListMapFunc creates and initializes a new SkipListMap ordering keys using
+// the provided comparator. The comparator must return a negative number if a is
+// less than b, zero if they are equal and a positive number if a is greater than
+// b. Keys the comparator considers equal are treated as the same key.
+func NewSkipListMapFunc[K any, V any](cmp func(a, b K) int) *SkipListMap[K, V] {
+	if cmp == nil {
+		panic("comparator cannot be nil")
+	}
+	return &SkipListMap[K, V]{
+		head: &skipListNode[K, V]{
+			next: make([]atomic.Value, skipListMaxLevel),
+		},
+		cmp: cmp,
+	}
+}
+
+// Get retrieves the value for a key. Get follows the same semantics of the
+// built-in map returning the value and a boolean indicating if the key exists.
+func (m *SkipListMap[K, V]) Get(key K) (V, bool) {
+	var preds, succs [skipListMaxLevel]*skipListNode[K, V]
+	level := m.find(key, &preds, &succs)
+	if level != -1 && succs[level].live() {
+		return succs[level].value(), true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains returns a boolean indicating if the key exists.
+func (m *SkipListMap[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set inserts a new key/value into the map or replaces the value for an existing
+// key. If the key didn't exist in the map and was inserted true is returned.
+// Otherwise, if they key was already existing returns false.
+func (m *SkipListMap[K, V]) Set(key K, val V) bool {
+	topLevel := m.randomLevel()
+	var preds, succs [skipListMaxLevel]*skipListNode[K, V]
+	for {
+		if level := m.find(key, &preds, &succs); level != -1 {
+			found := succs[level]
+			if atomic.LoadUint32(&found.marked) == 1 {
+				// The node is being deleted, retry once it has been unlinked
+				runtime.Gosched()
+				continue
+			}
+			for atomic.LoadUint32(&found.fullyLinked) == 0 {
+				runtime.Gosched()
+			}
+			found.val.Store(&val)
+			return false
+		}
+
+		locked, valid := lockPreds(&preds, topLevel, func(level int, pred *skipListNode[K, V]) bool {
+			succ := succs[level]
+			return atomic.LoadUint32(&pred.marked) == 0 &&
+				(succ == nil || atomic.LoadUint32(&succ.marked) == 0) &&
+				pred.loadNext(level) == succ
+		})
+		if !valid {
+			unlockPreds(&preds, locked)
+			continue
+		}
+
+		node := &skipListNode[K, V]{
+			key:  key,
+			next: make([]atomic.Value, topLevel),
+		}
+		node.val.Store(&val)
+		for level := 0; level < topLevel; level++ {
+			node.next[level].Store(succs[level])
+		}
+		for level := 0; level < topLevel; level++ {
+			preds[level].next[level].Store(node)
+		}
+		atomic.StoreUint32(&node.fullyLinked, 1)
+		unlockPreds(&preds, locked)
+		atomic.AddInt64(&m.size, 1)
+		return true
+	}
+}
+
+// Delete removes a key/value entry from the SkipListMap. If the key didn't exist
+// returns false, otherwise returns true if the entry was deleted.
+func (m *SkipListMap[K, V]) Delete(key K) bool {
+	var preds, succs [skipListMaxLevel]*skipListNode[K, V]
+	var victim *skipListNode[K, V]
+	for {
+		level := m.find(key, &preds, &succs)
+		if victim == nil {
+			// Only a fully linked node found at its top level can be deleted, a
+			// node found at a lower level is still being linked or unlinked.
+			if level == -1 || !succs[level].live() || len(succs[level].next)-1 != level {
+				return false
+			}
+			victim = succs[level]
+			victim.mu.Lock()
+			if atomic.LoadUint32(&victim.marked) == 1 {
+				victim.mu.Unlock()
+				return false
+			}
+			atomic.StoreUint32(&victim.marked, 1)
+		}
+
+		topLevel := len(victim.next)
+		locked, valid := lockPreds(&preds, topLevel, func(level int, pred *skipListNode[K, V]) bool {
+			return atomic.LoadUint32(&pred.marked) == 0 && pred.loadNext(level) == victim
+		})
+		if !valid {
+			unlockPreds(&preds, locked)
+			continue
+		}
+
+		for level := topLevel - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.loadNext(level))
+		}
+		victim.mu.Unlock()
+		unlockPreds(&preds, locked)
+		atomic.AddInt64(&m.size, -1)
+		return true
+	}
+}
+
+// Range iterates through the entries with keys greater than or equal to from and
+// strictly less than to in key order, passing the key/value pair to the provided
+// function. Iteration stops early if the function returns false.
+func (m *SkipListMap[K, V]) Range(from K, to K, fn func(key K, val V) bool) {
+	var preds, succs [skipListMaxLevel]*skipListNode[K, V]
+	m.find(from, &preds, &succs)
+	for n := succs[0]; n != nil && m.cmp(n.key, to) < 0; n = n.loadNext(0) {
+		if n.live() && !fn(n.key, n.value()) {
+			return
+		}
+	}
+}
+
+// ForEach iterates through the entries in ascending key order passing the
+// key/value pair to the provided function/closure.
+func (m *SkipListMap[K, V]) ForEach(fn func(key K, val V)) {
+	for n := m.head.loadNext(0); n != nil; n = n.loadNext(0) {
+		if n.live() {
+			fn(n.key, n.value())
+		}
+	}
+}
+
+// Keys returns the keys in ascending order.
+func (m *SkipListMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Size())
+	m.ForEach(func(key K, val V) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// Size returns the number of entries in the SkipListMap.
+func (m *SkipListMap[K, V]) Size() int {
+	return int(atomic.LoadInt64(&m.size))
+}
+
+// find searches for the key filling preds and succs with the nodes before and at
+// or after the key on every level. It returns the highest level the key was found
+// on, or -1 if the key wasn't found.
+func (m *SkipListMap[K, V]) find(key K, preds, succs *[skipListMaxLevel]*skipListNode[K, V]) int {
+	found := -1
+	pred := m.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		curr := pred.loadNext(level)
+		for curr != nil && m.cmp(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.loadNext(level)
+		}
+		if found == -1 && curr != nil && m.cmp(key, curr.key) == 0 {
+			found = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return found
+}
+
+// randomLevel returns the number of levels for a new node, where each additional
+// level has a probability of 1/2. The random bits are derived from an atomic
+// counter so concurrent writers don't contend on a shared random source.
+func (m *SkipListMap[K, V]) randomLevel() int {
+	// splitmix64
+	z := atomic.AddUint64(&m.seed, 0x9e3779b97f4a7c15)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return 1 + bits.TrailingZeros64(z|1<<(skipListMaxLevel-1))
+}
+
+// lockPreds locks the distinct predecessors on the levels below topLevel, in
+// order from the lowest level, validating each with the provided function. It
+// returns the highest level locked and whether all the predecessors are valid.
+func lockPreds[K any, V any](preds *[skipListMaxLevel]*skipListNode[K, V], topLevel int, validate func(level int, pred *skipListNode[K, V]) bool) (int, bool) {
+	locked := -1
+	var prev *skipListNode[K, V]
+	for level := 0; level < topLevel; level++ {
+		pred := preds[level]
+		if pred != prev {
+			pred.mu.Lock()
+			locked = level
+			prev = pred
+		}
+		if !validate(level, pred) {
+			return locked, false
+		}
+	}
+	return locked, true
+}
+
+// unlockPreds unlocks the distinct predecessors locked by lockPreds.
+func unlockPreds[K any, V any](preds *[skipListMaxLevel]*skipListNode[K, V], locked int) {
+	var prev *skipListNode[K, V]
+	for level := 0; level <= locked; level++ {
+		if preds[level] != prev {
+			preds[level].mu.Unlock()
+			prev = preds[level]
+		}
+	}
+}
+
+func (n *skipListNode[K, V]) loadNext(level int) *skipListNode[K, V] {
+	next, _ := n.next[level].Load().(*skipListNode[K, V])
+	return next
+}
+
+func (n *skipListNode[K, V]) value() V {
+	return *n.val.Load().(*V)
+}
+
+// live returns true if the node is fully linked and not being deleted.
+func (n *skipListNode[K, V]) live() bool {
+	return atomic.LoadUint32(&n.fullyLinked) == 1 && atomic.LoadUint32(&n.marked) == 0
+}
@@ -0,0 +1,131 @@
+package sync
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipListMap(t *testing.T) {
+	m := NewSkipListMap[int, string]()
+	assert.True(t, m.Set(2, "two"))
+	assert.True(t, m.Set(1, "one"))
+	assert.True(t, m.Set(3, "three"))
+	assert.False(t, m.Set(1, "uno"))
+
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, []int{1, 2, 3}, m.Keys())
+
+	val, ok := m.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "uno", val)
+	_, ok = m.Get(4)
+	assert.False(t, ok)
+	assert.True(t, m.Contains(3))
+
+	assert.True(t, m.Delete(2))
+	assert.False(t, m.Delete(2))
+	assert.False(t, m.Contains(2))
+	assert.Equal(t, []int{1, 3}, m.Keys())
+	assert.Equal(t, 2, m.Size())
+}
+
+func TestNewSkipListMapFunc(t *testing.T) {
+	m := NewSkipListMapFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m.Set("b", 1)
+	m.Set("A", 2)
+	m.Set("a", 3)
+	assert.Equal(t, []string{"A", "b"}, m.Keys())
+
+	val, _ := m.Get("a")
+	assert.Equal(t, 3, val)
+
+	assert.Panics(t, func() {
+		NewSkipListMapFunc[string, int](nil)
+	})
+}
+
+func TestSkipListMap_Range(t *testing.T) {
+	m := NewSkipListMap[int, int]()
+	for i := 0; i < 100; i += 10 {
+		m.Set(i, i*2)
+	}
+
+	var keys []int
+	m.Range(15, 50, func(key int, val int) bool {
+		assert.Equal(t, key*2, val)
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{20, 30, 40}, keys)
+
+	keys = nil
+	m.Range(0, 100, func(key int, val int) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	assert.Equal(t, []int{0, 10}, keys)
+
+	keys = nil
+	m.Range(50, 10, func(key int, val int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Empty(t, keys)
+}
+
+func TestSkipListMap_Concurrent(t *testing.T) {
+	m := NewSkipListMap[int, int]()
+	wg := sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := i*8 + g
+				m.Set(key, key)
+				if key%3 == 0 {
+					m.Delete(key)
+				}
+				// Concurrent readers and writers on overlapping keys
+				m.Set(i, i)
+				m.Get(i + 1)
+			}
+		}(g)
+	}
+	for r := 0; r < 2; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				prev := -1
+				m.Range(0, 8000, func(key int, val int) bool {
+					assert.Greater(t, key, prev)
+					prev = key
+					return true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	var expected []int
+	for key := 0; key < 8000; key++ {
+		if key%3 != 0 || key < 1000 {
+			expected = append(expected, key)
+		}
+	}
+	sort.Ints(expected)
+	assert.Equal(t, expected, m.Keys())
+	assert.Equal(t, len(expected), m.Size())
+	for _, key := range expected {
+		val, ok := m.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, key, val)
+	}
+}